@@ -64,14 +64,7 @@ func (c *Client) GetDataset(datasetID string, projectID string, teamID string, a
 		return nil, err
 	}
 	defer response.Body.Close()
-	var jsonRes map[string]interface{}
-	resBody, _ := io.ReadAll(response.Body)
-	err = json.Unmarshal(resBody, &jsonRes)
-	if err != nil {
-		log.Printf("[ERROR] CLIENT GET NODE | error when unmarshalling: %v", err)
-		return nil, err
-	}
-	return jsonRes, nil
+	return decodeResource(response, "Dataset", datasetID)
 }
 
 func (c *Client) DeleteDataset(datasetID string, projectID string, teamID string, activeIAM string) (map[string]interface{}, error) {
@@ -112,3 +105,9 @@ func (c *Client) DeleteDataset(datasetID string, projectID string, teamID string
 	return jsonRes, nil
 
 }
+
+// ListDatasets returns all datasets of a project.
+func (c *Client) ListDatasets(projectID string, teamID string, activeIAM string) ([]interface{}, error) {
+	url := c.Api_endpoint + "/teams/" + teamID + "/projects/" + projectID + "/datasets/"
+	return c.getCollection(url, activeIAM)
+}
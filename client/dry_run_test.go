@@ -0,0 +1,125 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/e2eterraformprovider/terraform-provider-tir/models"
+)
+
+// TestDryRunMutatingCalls runs every mutating call of the Client in dry run mode. Each one must
+// accept the synthetic response, so an apply completes, and none may reach the API.
+func TestDryRunMutatingCalls(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("dry run sent %s %s to the API", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	file := filepath.Join(t.TempDir(), "dry-run.json")
+	c := NewClient("key", "token", server.URL)
+	c.EnableDryRun(file)
+
+	withResponse := func(call func() (map[string]interface{}, error)) func() error {
+		return func() error {
+			response, err := call()
+			if err != nil {
+				return err
+			}
+			if data, _ := response["data"].(map[string]interface{}); data["id"] == nil {
+				t.Errorf("response %v has no data.id", response)
+			}
+			return nil
+		}
+	}
+	cases := []struct {
+		name string
+		call func() error
+	}{
+		{"NewNode", withResponse(func() (map[string]interface{}, error) {
+			return c.NewNode(&models.NodeCreate{}, "1", "2", "3")
+		})},
+		{"NewDataset", withResponse(func() (map[string]interface{}, error) {
+			return c.NewDataset(&models.Dataset{}, "2", "1", "3")
+		})},
+		{"NewIntegration", withResponse(func() (map[string]interface{}, error) {
+			return c.NewIntegration(&models.Integration{}, "2", "1", "3")
+		})},
+		{"NewEndoint", withResponse(func() (map[string]interface{}, error) {
+			return c.NewEndoint(&models.ModelEndpoint{}, "2", "1", "3")
+		})},
+		{"NewRepo", withResponse(func() (map[string]interface{}, error) {
+			return c.NewRepo(&models.ModelRepo{}, "2", "1", "3")
+		})},
+		{"NewNodeImage", withResponse(func() (map[string]interface{}, error) {
+			return c.NewNodeImage(&models.NodeImage{}, "4", "2", "1", "3")
+		})},
+		{"NewPrivateCluster", withResponse(func() (map[string]interface{}, error) {
+			return c.NewPrivateCluster(&models.PrivateCluster{}, "2", "1", "3")
+		})},
+		{"NewSSHKey", withResponse(func() (map[string]interface{}, error) {
+			return c.NewSSHKey(&models.SSHKey{}, "3")
+		})},
+		{"UpdateStartStopNode", withResponse(func() (map[string]interface{}, error) {
+			return c.UpdateStartStopNode("4", "2", "1", "3", true)
+		})},
+		{"UpdatePlanNode", withResponse(func() (map[string]interface{}, error) {
+			return c.UpdatePlanNode(&models.NodeAction{}, "2", "1", "3", "4")
+		})},
+		{"UpdateImage", withResponse(func() (map[string]interface{}, error) {
+			return c.UpdateImage(&models.ImageDetail{}, "2", "1", "3", "4")
+		})},
+		{"UpdateNodeName", withResponse(func() (map[string]interface{}, error) {
+			return c.UpdateNodeName("4", "2", "1", "3", "renamed")
+		})},
+		{"UpdateStartStopInference", func() error {
+			_, err := c.UpdateStartStopInference("4", "2", "1", "3", "stop")
+			return err
+		}},
+		{"UpdateEndpoint", withResponse(func() (map[string]interface{}, error) {
+			return c.UpdateEndpoint(&models.ModelEndpoint{}, "2", "1", "3", "4")
+		})},
+		{"ResizeNodeDisk", func() error { return c.ResizeNodeDisk("4", "2", "1", "3", 100) }},
+		{"UpdateNodeDatasets", func() error {
+			return c.UpdateNodeDatasets("4", "2", "1", "3", []models.NodeDatasetMount{{}}, true)
+		}},
+		{"UpdateNodeAutoShutdown", func() error {
+			return c.UpdateNodeAutoShutdown("4", "2", "1", "3", &models.NodeAutoShutdown{IdleMinutes: 30})
+		}},
+		{"UpdateNodeSSHKeys", func() error { return c.UpdateNodeSSHKeys("4", "2", "1", "3", []int{5}, true) }},
+		{"UpdateLabels", func() error {
+			return c.UpdateLabels(NodeCollection, "4", "2", "1", "3", map[string]string{"team": "ml"})
+		}},
+		{"DeleteNode", func() error { return c.DeleteNode("4", "2", "1", "3") }},
+		{"DeleteDataset", func() error { _, err := c.DeleteDataset("4", "2", "1", "3"); return err }},
+		{"DeleteIntegration", func() error { _, err := c.DeleteIntegration("4", "2", "1", "3"); return err }},
+		{"DeleteEndpoint", func() error { _, err := c.DeleteEndpoint("4", "2", "1", "3"); return err }},
+		{"DeleteRepo", func() error { _, err := c.DeleteRepo("4", "2", "1", "3"); return err }},
+		{"DeleteNodeImage", func() error { return c.DeleteNodeImage("4", "2", "1", "3") }},
+		{"DeletePrivateCluster", func() error { _, err := c.DeletePrivateCluster("4", "2", "1", "3"); return err }},
+		{"DeleteSSHKey", func() error { return c.DeleteSSHKey("4", "3") }},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.call(); err != nil {
+				t.Errorf("%s in dry run mode: %v", tc.name, err)
+			}
+		})
+	}
+
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var calls []DryRunCall
+	if err := json.Unmarshal(raw, &calls); err != nil {
+		t.Fatalf("%s is not a JSON list of calls: %v", file, err)
+	}
+	if len(calls) != len(cases) {
+		t.Errorf("%d calls recorded, want %d", len(calls), len(cases))
+	}
+}
@@ -28,12 +28,12 @@ func (c *Client) GetIAMS() (map[string]interface{}, error) {
 	req.Header.Add("Authorization", "Bearer "+c.Auth_token)
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Set("User-Agent", "terraform/e2e")
-	log.Println(req)
+	log.Println(RedactRequest(req))
 	response, err := c.HttpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
-	log.Println(response)
+	log.Println("response status", response.Status)
 	if response.StatusCode != http.StatusOK {
 		respBody := new(bytes.Buffer)
 		_, err := respBody.ReadFrom(response.Body)
@@ -45,7 +45,7 @@ func (c *Client) GetIAMS() (map[string]interface{}, error) {
 	defer response.Body.Close()
 	resBody, _ := io.ReadAll(response.Body)
 	stringresponse := string(resBody)
-	log.Printf("%s", stringresponse)
+	log.Printf("[DEBUG] response %s", RedactJSON(json.RawMessage(resBody)))
 	resBytes := []byte(stringresponse)
 	var jsonRes map[string]interface{}
 	err = json.Unmarshal(resBytes, &jsonRes)
@@ -18,7 +18,6 @@ func (c *Client) NewIntegration(item *models.Integration, projectID string, team
 	if err != nil {
 		return nil, err
 	}
-	log.Println("jatin111111")
 	params := req.URL.Query()
 	params.Add("apikey", c.Api_key)
 	params.Add("active_iam", activeIAM)
@@ -45,13 +44,34 @@ func (c *Client) NewIntegration(item *models.Integration, projectID string, team
 	return jsonRes, nil
 }
 
+func (c *Client) GetIntegration(integrationID string, projectID string, teamID string, activeIAM string) (map[string]interface{}, error) {
+	url := c.Api_endpoint + "/teams/" + teamID + "/projects/" + projectID + "/integrations/" + integrationID + "/"
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	params := req.URL.Query()
+	params.Add("apikey", c.Api_key)
+	params.Add("active_iam", activeIAM)
+	req.URL.RawQuery = params.Encode()
+	req.Header.Add("Authorization", "Bearer "+c.Auth_token)
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "terraform/e2e")
+	response, err := c.HttpClient.Do(req)
+	if err != nil {
+		log.Println("Error while Reading")
+		return nil, err
+	}
+	defer response.Body.Close()
+	return decodeResource(response, "Integration", integrationID)
+}
+
 func (c *Client) DeleteIntegration(integrationID string, projectID string, teamID string, activeIAM string) (map[string]interface{}, error) {
 	url := c.Api_endpoint + "/teams/" + teamID + "/projects/" + projectID + "/integrations/" + integrationID + "/"
 	req, err := http.NewRequest("DELETE", url, nil)
 	if err != nil {
 		return nil, err
 	}
-	log.Println("jatin111111DELETE")
 	params := req.URL.Query()
 	params.Add("apikey", c.Api_key)
 	params.Add("active_iam", activeIAM)
@@ -0,0 +1,47 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+)
+
+// Collections of the project scoped TIR resources that carry labels, as used by UpdateLabels.
+const (
+	NodeCollection           = "notebooks"
+	DatasetCollection        = "datasets"
+	ModelRepoCollection      = "serving/model"
+	EndpointCollection       = "serving/inference"
+	PrivateClusterCollection = "private-cluster"
+)
+
+// UpdateLabels replaces the labels of the resource resourceID in collection with labels.
+func (c *Client) UpdateLabels(collection string, resourceID string, projectID string, teamID string, activeIAM string, labels map[string]string) error {
+	jsonPayload, _ := json.Marshal(map[string]interface{}{"labels": labels})
+	url := c.Api_endpoint + "/teams/" + teamID + "/projects/" + projectID + "/" + collection + "/" + resourceID + "/"
+	req, err := http.NewRequest("PATCH", url, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return err
+	}
+	params := req.URL.Query()
+	params.Add("apikey", c.Api_key)
+	params.Add("active_iam", activeIAM)
+	req.URL.RawQuery = params.Encode()
+	req.Header.Add("Authorization", "Bearer "+c.Auth_token)
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "terraform/e2e")
+	log.Println(RedactRequest(req))
+	response, err := c.HttpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		body, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("got a non 2xx status code updating labels: %v - %s", response.StatusCode, string(body))
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+// listPageSize is the number of items requested per page when listing a collection.
+const listPageSize = 100
+
+// getCollection fetches every page of a TIR collection endpoint and returns the items of all
+// pages. Pages are requested until the API reports the last page or returns a short page.
+func (c *Client) getCollection(url string, activeIAM string) ([]interface{}, error) {
+	var items []interface{}
+	for page := 1; ; page++ {
+		req, err := http.NewRequest("GET", url, nil)
+		if err != nil {
+			return nil, err
+		}
+		params := req.URL.Query()
+		params.Add("apikey", c.Api_key)
+		params.Add("active_iam", activeIAM)
+		params.Add("page_no", strconv.Itoa(page))
+		params.Add("per_page", strconv.Itoa(listPageSize))
+		req.URL.RawQuery = params.Encode()
+		req.Header.Add("Authorization", "Bearer "+c.Auth_token)
+		req.Header.Add("Content-Type", "application/json")
+		req.Header.Set("User-Agent", "terraform/e2e")
+		log.Println(RedactRequest(req))
+		response, err := c.HttpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		resBody, _ := io.ReadAll(response.Body)
+		response.Body.Close()
+		if response.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("got a non 200 status code: %v - %s", response.StatusCode, string(resBody))
+		}
+		var jsonRes map[string]interface{}
+		if err := json.Unmarshal(resBody, &jsonRes); err != nil {
+			return nil, err
+		}
+		pageItems, _ := jsonRes["data"].([]interface{})
+		items = append(items, pageItems...)
+		lastPage, ok := jsonRes["total_page_number"].(float64)
+		if len(pageItems) < listPageSize || (ok && float64(page) >= lastPage) {
+			return items, nil
+		}
+	}
+}
@@ -24,7 +24,6 @@ func (c *Client) NewEndoint(item *models.ModelEndpoint, projectID string, teamID
 	if err != nil {
 		return nil, err
 	}
-	log.Println("jatin111111")
 	params := req.URL.Query()
 	params.Add("apikey", c.Api_key)
 	params.Add("active_iam", activeIAM)
@@ -33,13 +32,13 @@ func (c *Client) NewEndoint(item *models.ModelEndpoint, projectID string, teamID
 	req.Header.Add("Authorization", "Bearer "+c.Auth_token)
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Set("User-Agent", "terraform/e2e")
-	log.Println("sahil",req)
+	log.Println(RedactRequest(req))
 	response, err := c.HttpClient.Do(req)
 	if err != nil {
 		log.Println("Error while creating")
 		return nil, err
 	}
-	log.Println("response", response)
+	log.Println("response status", response.Status)
 	err = CheckResponseStatus(response)
 	if err != nil {
 		return nil, err
@@ -61,7 +60,6 @@ func (c *Client) GetEndpoint(endpointID string, projectID string, teamID string,
 	if err != nil {
 		return nil, err
 	}
-	log.Println("jatin111111GET")
 	params := req.URL.Query()
 	params.Add("apikey", c.Api_key)
 	params.Add("active_iam", activeIAM)
@@ -75,13 +73,11 @@ func (c *Client) GetEndpoint(endpointID string, projectID string, teamID string,
 		return nil, err
 	}
 	defer response.Body.Close()
-	resBody, _ := io.ReadAll(response.Body)
-	var jsonRes map[string]interface{}
-	err = json.Unmarshal(resBody, &jsonRes)
+	jsonRes, err := decodeResource(response, "Model endpoint", endpointID)
 	if err != nil {
 		return nil, err
 	}
-	log.Println("json is ", jsonRes)
+	log.Printf("[DEBUG] response %s", RedactJSON(jsonRes))
 	return jsonRes, nil
 }
 
@@ -129,20 +125,25 @@ func (c *Client) UpdateStartStopInference(endpointID string, projectID string, t
 	if err != nil {
 		return nil, err
 	}
-	log.Println(response)
+	defer response.Body.Close()
+	log.Println("response status", response.Status)
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		resBody, _ := io.ReadAll(response.Body)
+		return nil, fmt.Errorf("got a non 200 status code: %v - %s", response.StatusCode, string(resBody))
+	}
 	return nil, nil
 }
 
 func (c *Client) UpdateEndpoint(item *models.ModelEndpoint, projectID string, teamID string, activeIAM string, endpointID string) (map[string]interface{}, error) {
 	repoJSON, _ := json.Marshal(item)
 	buf := bytes.NewBuffer(repoJSON)
-	log.Println("request json", buf)
+	log.Printf("[DEBUG] request %s", RedactJSON(item))
 	url := c.Api_endpoint + "/teams/" + teamID + "/projects/" + projectID + "/serving/inference/" + endpointID + "/"
 	req, err := http.NewRequest("PUT", url, buf)
 	if err != nil {
 		return nil, err
 	}
-	log.Println("jatin Update", req)
+	log.Println(RedactRequest(req))
 	params := req.URL.Query()
 	params.Add("apikey", c.Api_key)
 	params.Add("active_iam", activeIAM)
@@ -158,7 +159,7 @@ func (c *Client) UpdateEndpoint(item *models.ModelEndpoint, projectID string, te
 	if response.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("some problem occured")
 	}
-	log.Println("response update client", response)
+	log.Println("response status", response.Status)
 	defer response.Body.Close()
 	resBody, _ := io.ReadAll(response.Body)
 	var jsonRes map[string]interface{}
@@ -167,7 +168,7 @@ func (c *Client) UpdateEndpoint(item *models.ModelEndpoint, projectID string, te
 		log.Println("Unmarshal", err)
 		return nil, err
 	}
-	log.Println("json is ", jsonRes)
+	log.Printf("[DEBUG] response %s", RedactJSON(jsonRes))
 
 	return jsonRes, nil
 }
@@ -294,6 +295,7 @@ func SetSchemaFromResponse(d *schema.ResourceData, response map[string]interface
 	// Set resource details
 	resourceDetails, ok := customEndpointDetails["resource_details"].(map[string]interface{})
 	if ok {
+		resourceDetails["env_variables"] = scrubEnvVariables(d, resourceDetails["env_variables"])
 		resourceDetailsList := []map[string]interface{}{resourceDetails}
 		if err := d.Set("resource_details", resourceDetailsList); err != nil {
 			return fmt.Errorf("failed to set 'resource_details': %v", err)
@@ -305,12 +307,6 @@ func SetSchemaFromResponse(d *schema.ResourceData, response map[string]interface
 		return fmt.Errorf("failed to set 'public_ip': %v", err)
 	}
 
-	if d.Get("status") == "stopped" {
-		d.Set("stop_inference", "stop")
-	} else {
-		d.Set("stop_inference", "start")
-	}
-
 	return nil
 }
 
@@ -336,12 +332,12 @@ func (c *Client) GetPlansModelEndpoint( activeIAM string, framework string) (map
 	req.Header.Add("Authorization", "Bearer "+c.Auth_token)
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Set("User-Agent", "terraform/e2e")
-	log.Println(req)
+	log.Println(RedactRequest(req))
 	response, err := c.HttpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
-	log.Println(response)
+	log.Println("response status", response.Status)
 	if response.StatusCode != http.StatusOK {
 		respBody := new(bytes.Buffer)
 		_, err := respBody.ReadFrom(response.Body)
@@ -353,7 +349,7 @@ func (c *Client) GetPlansModelEndpoint( activeIAM string, framework string) (map
 	defer response.Body.Close()
 	resBody, _ := io.ReadAll(response.Body)
 	stringresponse := string(resBody)
-	log.Printf("%s", stringresponse)
+	log.Printf("[DEBUG] response %s", RedactJSON(json.RawMessage(resBody)))
 	resBytes := []byte(stringresponse)
 	var jsonRes map[string]interface{}
 	err = json.Unmarshal(resBytes, &jsonRes)
@@ -362,4 +358,36 @@ func (c *Client) GetPlansModelEndpoint( activeIAM string, framework string) (map
 		return nil, err
 	}
 	return jsonRes, nil
-}
\ No newline at end of file
+}
+
+// scrubEnvVariables keeps the values of env variables set through value_wo out of state when
+// reading an endpoint, using the keys recorded in env_variables_wo_keys.
+func scrubEnvVariables(d *schema.ResourceData, envVariables interface{}) interface{} {
+	envList, ok := envVariables.([]interface{})
+	if !ok {
+		return envVariables
+	}
+	writeOnlyKeys := make(map[string]bool)
+	if keys, ok := d.Get("env_variables_wo_keys").(*schema.Set); ok {
+		for _, key := range keys.List() {
+			writeOnlyKeys[key.(string)] = true
+		}
+	}
+	for _, env := range envList {
+		envMap, ok := env.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		key := fmt.Sprintf("%v", envMap["key"])
+		if writeOnlyKeys[key] {
+			envMap["value"] = ""
+		}
+	}
+	return envList
+}
+
+// ListEndpoints returns all inference endpoints of a project.
+func (c *Client) ListEndpoints(projectID string, teamID string, activeIAM string) ([]interface{}, error) {
+	url := c.Api_endpoint + "/teams/" + teamID + "/projects/" + projectID + "/serving/inference/"
+	return c.getCollection(url, activeIAM)
+}
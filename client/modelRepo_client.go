@@ -20,7 +20,6 @@ func (c *Client) NewRepo(item *models.ModelRepo, projectID string, teamID string
 	if err != nil {
 		return nil, err
 	}
-	log.Println("jatin111111")
 	params := req.URL.Query()
 	params.Add("apikey", c.Api_key)
 	params.Add("active_iam", activeIAM)
@@ -69,13 +68,7 @@ func (c *Client) GetRepo(repoID string, projectID string, teamID string, activeI
 	}
 	log.Println("[INFO] After Calling API")
 	defer response.Body.Close()
-	resBody, _ := io.ReadAll(response.Body)
-	var jsonRes map[string]interface{}
-	err = json.Unmarshal(resBody, &jsonRes)
-	if err != nil {
-		return nil, err
-	}
-	return jsonRes, nil
+	return decodeResource(response, "Model repository", repoID)
 }
 
 func (c *Client) DeleteRepo(repoID string, projectID string, teamID string, activeIAM string) (map[string]interface{}, error) {
@@ -111,3 +104,9 @@ func (c *Client) DeleteRepo(repoID string, projectID string, teamID string, acti
 	}
 	return jsonRes, nil
 }
+
+// ListRepos returns all model repositories of a project.
+func (c *Client) ListRepos(projectID string, teamID string, activeIAM string) ([]interface{}, error) {
+	url := c.Api_endpoint + "/teams/" + teamID + "/projects/" + projectID + "/serving/model/"
+	return c.getCollection(url, activeIAM)
+}
@@ -0,0 +1,98 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/e2eterraformprovider/terraform-provider-tir/models"
+)
+
+// NewNodeImage starts saving the container of the node nodeID as a custom image. The image is
+// built in the background, GetNodeImage reports when it is ready.
+func (c *Client) NewNodeImage(item *models.NodeImage, nodeID string, projectID string, teamID string, activeIAM string) (map[string]interface{}, error) {
+	jsonPayload, _ := json.Marshal(item)
+	url := c.Api_endpoint + "/teams/" + teamID + "/projects/" + projectID + "/notebooks/" + nodeID + "/save_image/"
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return nil, err
+	}
+	params := req.URL.Query()
+	params.Add("apikey", c.Api_key)
+	params.Add("active_iam", activeIAM)
+	req.URL.RawQuery = params.Encode()
+	req.Header.Add("Authorization", "Bearer "+c.Auth_token)
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "terraform/e2e")
+	log.Println(RedactRequest(req))
+	response, err := c.HttpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if err := CheckResponseStatus(response); err != nil {
+		return nil, err
+	}
+	resBody, _ := io.ReadAll(response.Body)
+	var jsonRes map[string]interface{}
+	if err := json.Unmarshal(resBody, &jsonRes); err != nil {
+		return nil, err
+	}
+	return jsonRes, nil
+}
+
+// GetNodeImage returns the saved image imageID of a project.
+func (c *Client) GetNodeImage(imageID string, projectID string, teamID string, activeIAM string) (map[string]interface{}, error) {
+	url := c.Api_endpoint + "/teams/" + teamID + "/projects/" + projectID + "/saved_images/" + imageID + "/"
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	params := req.URL.Query()
+	params.Add("apikey", c.Api_key)
+	params.Add("active_iam", activeIAM)
+	req.URL.RawQuery = params.Encode()
+	req.Header.Add("Authorization", "Bearer "+c.Auth_token)
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "terraform/e2e")
+	log.Println(RedactRequest(req))
+	response, err := c.HttpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	return decodeResource(response, "Node image", imageID)
+}
+
+// DeleteNodeImage deletes the saved image imageID. Nodes already running it are not affected.
+func (c *Client) DeleteNodeImage(imageID string, projectID string, teamID string, activeIAM string) error {
+	url := c.Api_endpoint + "/teams/" + teamID + "/projects/" + projectID + "/saved_images/" + imageID + "/"
+	req, err := http.NewRequest("DELETE", url, nil)
+	if err != nil {
+		return err
+	}
+	params := req.URL.Query()
+	params.Add("apikey", c.Api_key)
+	params.Add("active_iam", activeIAM)
+	req.URL.RawQuery = params.Encode()
+	req.Header.Add("Authorization", "Bearer "+c.Auth_token)
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "terraform/e2e")
+	log.Println(RedactRequest(req))
+	response, err := c.HttpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode == http.StatusNotFound {
+		return &NotFoundError{Kind: "Node image", ID: imageID}
+	}
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		body, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("got a non 2xx status code: %v - %s", response.StatusCode, string(body))
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// NotFoundError is returned by the Get calls when the requested TIR resource does not exist
+// anymore, either because TIR answered 404 or because it returned no data for the ID.
+type NotFoundError struct {
+	Kind string
+	ID   string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("404 Not Found: %s with ID %s does not exist", e.Kind, e.ID)
+}
+
+// IsNotFound reports whether err means the requested TIR resource does not exist.
+func IsNotFound(err error) bool {
+	var notFound *NotFoundError
+	if errors.As(err, &notFound) {
+		return true
+	}
+	return err != nil && strings.Contains(err.Error(), "404 Not Found")
+}
+
+// decodeResource decodes the response of a Get call on a single TIR resource. A 404 response
+// and a response whose data is null are reported as a NotFoundError, any other non 200 status
+// as an error carrying the response body.
+func decodeResource(response *http.Response, kind string, id string) (map[string]interface{}, error) {
+	resBody, _ := io.ReadAll(response.Body)
+	if response.StatusCode == http.StatusNotFound {
+		return nil, &NotFoundError{Kind: kind, ID: id}
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("got a non-200 status code: %v - %s", response.StatusCode, string(resBody))
+	}
+	var jsonRes map[string]interface{}
+	if err := json.Unmarshal(resBody, &jsonRes); err != nil {
+		return nil, err
+	}
+	if jsonRes["data"] == nil {
+		return nil, &NotFoundError{Kind: kind, ID: id}
+	}
+	if _, ok := jsonRes["data"].(map[string]interface{}); !ok {
+		return nil, fmt.Errorf("unexpected response for %s %s: %s", kind, id, string(resBody))
+	}
+	return jsonRes, nil
+}
@@ -10,11 +10,18 @@ import (
 	"github.com/e2eterraformprovider/terraform-provider-tir/models"
 )
 
+// DefaultApiEndpoint is the TIR API used when the provider does not set api_endpoint.
+const DefaultApiEndpoint = "https://api.e2enetworks.com/myaccount/api/v1/gpu"
+
 type Client struct {
 	Api_key      string
 	Auth_token   string
 	Api_endpoint string
 	HttpClient   *http.Client
+	// DefaultLabels are the provider default_labels, merged into the labels of every resource.
+	DefaultLabels map[string]string
+	// DryRunFile is where mutating calls are recorded in dry run mode, empty when disabled.
+	DryRunFile string
 }
 
 func NewClient(api_key string, auth_token string, api_endpoint string) *Client {
@@ -83,24 +90,7 @@ func (c *Client) GetNode(nodeId string, projectID, teamID string, activeIAM stri
 	}
 	defer response.Body.Close()
 	log.Printf("[INFO] CLIENT NODE READ | response code: %d", response.StatusCode)
-	if response.StatusCode == http.StatusNotFound {
-		log.Println("[INFO] Node not found, returning explicit 404 error")
-		return nil, fmt.Errorf("404 Not Found: Node with ID %s does not exist", nodeId)
-	}
-	if response.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(response.Body)
-		return nil, fmt.Errorf("got a non-200 status code: %v - %s", response.StatusCode, string(body))
-	}
-	resBody, _ := io.ReadAll(response.Body)
-	var jsonRes map[string]interface{}
-	err = json.Unmarshal(resBody, &jsonRes)
-	if err != nil {
-		return nil, err
-	}
-	if response.StatusCode != http.StatusOK {
-		return jsonRes, nil
-	}
-	return jsonRes, nil
+	return decodeResource(response, "Node", nodeId)
 }
 
 func (c *Client) DeleteNode(nodeId string, projectID string, teamID string, activeIAM string) error {
@@ -111,8 +101,7 @@ func (c *Client) DeleteNode(nodeId string, projectID string, teamID string, acti
 		return err
 	}
 	log.Println("[INFO] CLIENT | NODE READ")
-	log.Println(req)
-	log.Println(req.Body)
+	log.Println(RedactRequest(req))
 	params := req.URL.Query()
 
 	params.Add("apikey", c.Api_key)
@@ -159,15 +148,16 @@ func (c *Client) UpdateStartStopNode(nodeId string, projectID string, teamID str
 	if err != nil {
 		return nil, err
 	}
+	defer response.Body.Close()
 	resBody, _ := io.ReadAll(response.Body)
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, fmt.Errorf("got a non 200 status code: %v - %s", response.StatusCode, string(resBody))
+	}
 	var jsonRes map[string]interface{}
 	err = json.Unmarshal(resBody, &jsonRes)
 	if err != nil {
 		return nil, err
 	}
-	if response.StatusCode != http.StatusOK {
-		return jsonRes, nil
-	}
 	return jsonRes, nil
 }
 
@@ -186,11 +176,8 @@ func (c *Client) UpdatePlanNode(item *models.NodeAction, projectID string, teamI
 	req.Header.Add("Authorization", "Bearer "+c.Auth_token)
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Set("User-Agent", "terraform/e2e")
-	log.Println("this is the reqset", req)
-	log.Println("this is the plan change req", req.Body)
+	log.Println(RedactRequest(req))
 	response, err := c.HttpClient.Do(req)
-	log.Println("after cycle", response.Body)
-	log.Println("this is error", err)
 	if err != nil {
 		log.Println("Error")
 		return nil, err
@@ -217,7 +204,7 @@ func (c *Client) UpdateImage(item *models.ImageDetail, projectID string, teamID
 	if err != nil {
 		return nil, err
 	}
-	log.Println("this is the req", req)
+	log.Println(RedactRequest(req))
 	params := req.URL.Query()
 	params.Add("apikey", c.Api_key)
 	params.Add("active_iam", activeIAM)
@@ -259,7 +246,7 @@ func (c *Client) GetImages(activeIAM string) (map[string]interface{}, error) {
 	req.Header.Add("Authorization", "Bearer "+c.Auth_token)
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Set("User-Agent", "terraform/e2e")
-	log.Println(req)
+	log.Println(RedactRequest(req))
 	response, err := c.HttpClient.Do(req)
 	if err != nil {
 		return nil, err
@@ -275,7 +262,7 @@ func (c *Client) GetImages(activeIAM string) (map[string]interface{}, error) {
 	defer response.Body.Close()
 	resBody, _ := io.ReadAll(response.Body)
 	stringresponse := string(resBody)
-	log.Printf("%s", stringresponse)
+	log.Printf("[DEBUG] response %s", RedactJSON(json.RawMessage(resBody)))
 	resBytes := []byte(stringresponse)
 	var jsonRes map[string]interface{}
 	err = json.Unmarshal(resBytes, &jsonRes)
@@ -304,12 +291,12 @@ func (c *Client) GetPlans(activeIAM string, image_name string, image_version str
 	req.Header.Add("Authorization", "Bearer "+c.Auth_token)
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Set("User-Agent", "terraform/e2e")
-	log.Println(req)
+	log.Println(RedactRequest(req))
 	response, err := c.HttpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
-	log.Println(response)
+	log.Println("response status", response.Status)
 	if response.StatusCode != http.StatusOK {
 		respBody := new(bytes.Buffer)
 		_, err := respBody.ReadFrom(response.Body)
@@ -321,7 +308,7 @@ func (c *Client) GetPlans(activeIAM string, image_name string, image_version str
 	defer response.Body.Close()
 	resBody, _ := io.ReadAll(response.Body)
 	stringresponse := string(resBody)
-	log.Printf("%s", stringresponse)
+	log.Printf("[DEBUG] response %s", RedactJSON(json.RawMessage(resBody)))
 	resBytes := []byte(stringresponse)
 	var jsonRes map[string]interface{}
 	err = json.Unmarshal(resBytes, &jsonRes)
@@ -352,12 +339,12 @@ func (c *Client) UpdateNodeName(nodeID string, projectID string, teamID string,
 	req.Header.Add("Authorization", "Bearer "+c.Auth_token)
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Set("User-Agent", "terraform/e2e")
-	log.Println(req)
+	log.Println(RedactRequest(req))
 	response, err := c.HttpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
-	log.Println(response)
+	log.Println("response status", response.Status)
 	if response.StatusCode != http.StatusOK {
 		respBody := new(bytes.Buffer)
 		_, err := respBody.ReadFrom(response.Body)
@@ -369,7 +356,7 @@ func (c *Client) UpdateNodeName(nodeID string, projectID string, teamID string,
 	defer response.Body.Close()
 	resBody, _ := io.ReadAll(response.Body)
 	stringresponse := string(resBody)
-	log.Printf("%s", stringresponse)
+	log.Printf("[DEBUG] response %s", RedactJSON(json.RawMessage(resBody)))
 	resBytes := []byte(stringresponse)
 	var jsonRes map[string]interface{}
 	err = json.Unmarshal(resBytes, &jsonRes)
@@ -394,3 +381,108 @@ func CheckResponseStatus(response *http.Response) error {
 	}
 	return nil
 }
+
+// ListNodes returns all notebooks of a project.
+func (c *Client) ListNodes(projectID string, teamID string, activeIAM string) ([]interface{}, error) {
+	url := c.Api_endpoint + "/teams/" + teamID + "/projects/" + projectID + "/notebooks/"
+	return c.getCollection(url, activeIAM)
+}
+
+// ResizeNodeDisk grows the workspace disk of a node to sizeInGB. TIR cannot shrink a workspace.
+func (c *Client) ResizeNodeDisk(nodeID string, projectID string, teamID string, activeIAM string, sizeInGB int) error {
+	jsonPayload, _ := json.Marshal(map[string]interface{}{"disk_size_in_gb": sizeInGB})
+	urlNode := c.Api_endpoint + "/teams/" + teamID + "/projects/" + projectID + "/notebooks/" + nodeID + "/actions/"
+	req, err := http.NewRequest("PUT", urlNode, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return err
+	}
+	params := req.URL.Query()
+	params.Add("apikey", c.Api_key)
+	params.Add("active_iam", activeIAM)
+	params.Add("action", "resize_workspace")
+	req.URL.RawQuery = params.Encode()
+	req.Header.Add("Authorization", "Bearer "+c.Auth_token)
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "terraform/e2e")
+	log.Println(RedactRequest(req))
+	response, err := c.HttpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("got a non 200 status code: %v - %s", response.StatusCode, string(body))
+	}
+	return nil
+}
+
+// UpdateNodeDatasets mounts (attach true) or unmounts the datasets of mounts on the running node
+// nodeID. Only the dataset IDs of mounts are used to unmount.
+func (c *Client) UpdateNodeDatasets(nodeID string, projectID string, teamID string, activeIAM string, mounts []models.NodeDatasetMount, attach bool) error {
+	action := "detach_datasets"
+	if attach {
+		action = "attach_datasets"
+	}
+	jsonPayload, _ := json.Marshal(map[string]interface{}{"dataset_mounts": mounts})
+	urlNode := c.Api_endpoint + "/teams/" + teamID + "/projects/" + projectID + "/notebooks/" + nodeID + "/actions/"
+	req, err := http.NewRequest("PUT", urlNode, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return err
+	}
+	params := req.URL.Query()
+	params.Add("apikey", c.Api_key)
+	params.Add("active_iam", activeIAM)
+	params.Add("action", action)
+	req.URL.RawQuery = params.Encode()
+	req.Header.Add("Authorization", "Bearer "+c.Auth_token)
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "terraform/e2e")
+	log.Println(RedactRequest(req))
+	response, err := c.HttpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("got a non 200 status code: %v - %s", response.StatusCode, string(body))
+	}
+	return nil
+}
+
+// UpdateNodeAutoShutdown replaces the idle shutdown and start/stop schedule of the node nodeID
+// with settings. A nil settings turns both off.
+func (c *Client) UpdateNodeAutoShutdown(nodeID string, projectID string, teamID string, activeIAM string, settings *models.NodeAutoShutdown) error {
+	method := "PUT"
+	var body io.Reader
+	if settings == nil {
+		method = "DELETE"
+	} else {
+		jsonPayload, _ := json.Marshal(settings)
+		body = bytes.NewBuffer(jsonPayload)
+	}
+	urlNode := c.Api_endpoint + "/teams/" + teamID + "/projects/" + projectID + "/notebooks/" + nodeID + "/auto-shutdown/"
+	req, err := http.NewRequest(method, urlNode, body)
+	if err != nil {
+		return err
+	}
+	params := req.URL.Query()
+	params.Add("apikey", c.Api_key)
+	params.Add("active_iam", activeIAM)
+	req.URL.RawQuery = params.Encode()
+	req.Header.Add("Authorization", "Bearer "+c.Auth_token)
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "terraform/e2e")
+	log.Println(RedactRequest(req))
+	response, err := c.HttpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		respBody, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("got a non 2xx status code: %v - %s", response.StatusCode, string(respBody))
+	}
+	return nil
+}
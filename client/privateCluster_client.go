@@ -32,15 +32,16 @@ func (c *Client) NewPrivateCluster(item *models.PrivateCluster, projectID string
 		log.Println("Error while creating")
 		return nil, err
 	}
-	if response.StatusCode != http.StatusOK {
+	defer response.Body.Close()
+	// TIR answers with 200 today, any 2xx means the cluster was created.
+	if response.StatusCode < 200 || response.StatusCode > 299 {
 		respBody := new(bytes.Buffer)
 		_, err := respBody.ReadFrom(response.Body)
 		if err != nil {
-			return nil, fmt.Errorf("got a non 200 status code: %v", response.StatusCode)
+			return nil, fmt.Errorf("got a non 2xx status code: %v", response.StatusCode)
 		}
-		return nil, fmt.Errorf("got a non 200 status code: %v - %s", response.StatusCode, respBody.String())
+		return nil, fmt.Errorf("got a non 2xx status code: %v - %s", response.StatusCode, respBody.String())
 	}
-	defer response.Body.Close()
 	resBody, _ := io.ReadAll(response.Body)
 	var jsonRes map[string]interface{}
 	err = json.Unmarshal(resBody, &jsonRes)
@@ -50,6 +51,28 @@ func (c *Client) NewPrivateCluster(item *models.PrivateCluster, projectID string
 	return jsonRes, nil
 }
 
+func (c *Client) GetPrivateCluster(privateClusterID string, projectID string, teamID string, activeIAM string) (map[string]interface{}, error) {
+	url := c.Api_endpoint + "/teams/" + teamID + "/projects/" + projectID + "/private-cluster/" + privateClusterID + "/"
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	params := req.URL.Query()
+	params.Add("apikey", c.Api_key)
+	params.Add("active_iam", activeIAM)
+	req.URL.RawQuery = params.Encode()
+	req.Header.Add("Authorization", "Bearer "+c.Auth_token)
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "terraform/e2e")
+	response, err := c.HttpClient.Do(req)
+	if err != nil {
+		log.Println("Error while Reading")
+		return nil, err
+	}
+	defer response.Body.Close()
+	return decodeResource(response, "Private cluster", privateClusterID)
+}
+
 func (c *Client) DeletePrivateCluster(privateClusterID string, projectID string, teamID string, activeIAM string) (map[string]interface{}, error) {
 	url := c.Api_endpoint + "/teams/" + teamID + "/projects/" + projectID + "/private-cluster/" + privateClusterID + "/"
 	req, err := http.NewRequest("DELETE", url, nil)
@@ -89,12 +112,12 @@ func (c *Client) GetPlansPrivateCluster( activeIAM string) (map[string]interface
 	req.Header.Add("Authorization", "Bearer "+c.Auth_token)
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Set("User-Agent", "terraform/e2e")
-	log.Println(req)
+	log.Println(RedactRequest(req))
 	response, err := c.HttpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
-	log.Println(response)
+	log.Println("response status", response.Status)
 	if response.StatusCode != http.StatusOK {
 		respBody := new(bytes.Buffer)
 		_, err := respBody.ReadFrom(response.Body)
@@ -106,7 +129,7 @@ func (c *Client) GetPlansPrivateCluster( activeIAM string) (map[string]interface
 	defer response.Body.Close()
 	resBody, _ := io.ReadAll(response.Body)
 	stringresponse := string(resBody)
-	log.Printf("%s", stringresponse)
+	log.Printf("[DEBUG] response %s", RedactJSON(json.RawMessage(resBody)))
 	resBytes := []byte(stringresponse)
 	var jsonRes map[string]interface{}
 	err = json.Unmarshal(resBytes, &jsonRes)
@@ -115,4 +138,10 @@ func (c *Client) GetPlansPrivateCluster( activeIAM string) (map[string]interface
 		return nil, err
 	}
 	return jsonRes, nil
-}
\ No newline at end of file
+}
+
+// ListPrivateClusters returns all private clusters of a project.
+func (c *Client) ListPrivateClusters(projectID string, teamID string, activeIAM string) ([]interface{}, error) {
+	url := c.Api_endpoint + "/teams/" + teamID + "/projects/" + projectID + "/private-cluster/"
+	return c.getCollection(url, activeIAM)
+}
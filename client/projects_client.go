@@ -29,12 +29,12 @@ func (c *Client) GetProjects(activeIAM string, teamID string) (map[string]interf
 	req.Header.Add("Authorization", "Bearer "+c.Auth_token)
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Set("User-Agent", "terraform/e2e")
-	log.Println(req)
+	log.Println(RedactRequest(req))
 	response, err := c.HttpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
-	log.Println(response)
+	log.Println("response status", response.Status)
 	if response.StatusCode != http.StatusOK {
 		respBody := new(bytes.Buffer)
 		_, err := respBody.ReadFrom(response.Body)
@@ -46,7 +46,7 @@ func (c *Client) GetProjects(activeIAM string, teamID string) (map[string]interf
 	defer response.Body.Close()
 	resBody, _ := io.ReadAll(response.Body)
 	stringresponse := string(resBody)
-	log.Printf("%s", stringresponse)
+	log.Printf("[DEBUG] response %s", RedactJSON(json.RawMessage(resBody)))
 	resBytes := []byte(stringresponse)
 	var jsonRes map[string]interface{}
 	err = json.Unmarshal(resBytes, &jsonRes)
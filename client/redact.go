@@ -0,0 +1,70 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// secretKeys are JSON keys whose values are never written to the provider logs.
+var secretKeys = map[string]bool{
+	"access_key":         true,
+	"secret_key":         true,
+	"hugging_face_token": true,
+	"token":              true,
+	"password":           true,
+	"apikey":             true,
+	"api_key":            true,
+	"auth_token":         true,
+	"public_key":         true,
+	"ssh_key":            true,
+}
+
+const redacted = "***"
+
+// RedactRequest describes a request for the logs without the api key and bearer token.
+func RedactRequest(req *http.Request) string {
+	query := req.URL.Query()
+	if query.Has("apikey") {
+		query.Set("apikey", redacted)
+	}
+	return req.Method + " " + req.URL.Path + "?" + query.Encode()
+}
+
+// RedactJSON marshals v for the logs, replacing secret values and env variable values with ***.
+func RedactJSON(v interface{}) string {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return "<unprintable>"
+	}
+	var generic interface{}
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return "<unprintable>"
+	}
+	redacted, _ := json.Marshal(redactValue(generic, false))
+	return string(redacted)
+}
+
+func redactValue(v interface{}, inEnv bool) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for key, item := range value {
+			lowerKey := strings.ToLower(key)
+			if secretKeys[lowerKey] || (inEnv && lowerKey == "value") {
+				if item != nil && item != "" {
+					value[key] = redacted
+				}
+				continue
+			}
+			value[key] = redactValue(item, inEnv || lowerKey == "env_variables" || lowerKey == "integration_details")
+		}
+		return value
+	case []interface{}:
+		for i, item := range value {
+			value[i] = redactValue(item, inEnv)
+		}
+		return value
+	default:
+		return v
+	}
+}
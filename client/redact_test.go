@@ -0,0 +1,102 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestRedactJSON(t *testing.T) {
+	cases := []struct {
+		name  string
+		value interface{}
+		want  string
+	}{
+		{
+			name:  "secret keys",
+			value: map[string]interface{}{"name": "bucket", "access_key": "AK", "Secret_Key": "SK"},
+			want:  `{"Secret_Key":"***","access_key":"***","name":"bucket"}`,
+		},
+		{
+			name:  "empty secrets are kept",
+			value: map[string]interface{}{"password": "", "token": nil},
+			want:  `{"password":"","token":null}`,
+		},
+		{
+			name: "env variable values",
+			value: map[string]interface{}{
+				"env_variables": []interface{}{
+					map[string]interface{}{"key": "HF_HOME", "value": "/mnt"},
+				},
+				"value": "not an env",
+			},
+			want: `{"env_variables":[{"key":"HF_HOME","value":"***"}],"value":"not an env"}`,
+		},
+		{
+			name: "integration details",
+			value: map[string]interface{}{
+				"integration_details": map[string]interface{}{"hugging_face_token": "hf_x", "value": "v"},
+			},
+			want: `{"integration_details":{"hugging_face_token":"***","value":"***"}}`,
+		},
+		{
+			name:  "nested secrets",
+			value: map[string]interface{}{"data": []interface{}{map[string]interface{}{"ssh_key": "ssh-rsa AAA", "id": 1}}},
+			want:  `{"data":[{"id":1,"ssh_key":"***"}]}`,
+		},
+		{
+			name:  "raw message",
+			value: json.RawMessage(`{"api_key":"k","status":"ok"}`),
+			want:  `{"api_key":"***","status":"ok"}`,
+		},
+		{
+			name: "struct",
+			value: struct {
+				Token string `json:"token"`
+			}{Token: "t"},
+			want: `{"token":"***"}`,
+		},
+		{
+			name:  "unprintable",
+			value: make(chan int),
+			want:  "<unprintable>",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := RedactJSON(tc.value); got != tc.want {
+				t.Errorf("RedactJSON() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRedactRequest(t *testing.T) {
+	cases := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{
+			name: "api key",
+			url:  "https://api.example.com/api/v1/teams/1/projects/2/notebooks/?apikey=secret&location=Delhi",
+			want: "GET /api/v1/teams/1/projects/2/notebooks/?apikey=%2A%2A%2A&location=Delhi",
+		},
+		{
+			name: "no api key",
+			url:  "https://api.example.com/api/v1/notebooks/?location=Delhi",
+			want: "GET /api/v1/notebooks/?location=Delhi",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", tc.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := RedactRequest(req); got != tc.want {
+				t.Errorf("RedactRequest() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
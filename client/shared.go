@@ -0,0 +1,32 @@
+package client
+
+import "sync"
+
+// SharedClient hands the same configured Client to the SDKv2 and the plugin framework halves of
+// the muxed provider. Terraform configures both halves from the same provider block, the first
+// one to be configured builds the Client and the other one reuses it.
+type SharedClient struct {
+	mu     sync.Mutex
+	client *Client
+}
+
+// NewSharedClient returns an empty SharedClient, the Client is built on the first Configure.
+func NewSharedClient() *SharedClient {
+	return &SharedClient{}
+}
+
+// Configure returns the shared Client, building it when it does not exist yet or when it was
+// built from a different configuration. defaultLabels replaces the default labels of the Client,
+// a non empty dryRunFile switches the Client to dry run mode.
+func (s *SharedClient) Configure(api_key string, auth_token string, api_endpoint string, defaultLabels map[string]string, dryRunFile string) *Client {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.client == nil || s.client.Api_key != api_key || s.client.Auth_token != auth_token || s.client.Api_endpoint != api_endpoint || s.client.DryRunFile != dryRunFile {
+		s.client = NewClient(api_key, auth_token, api_endpoint)
+		if dryRunFile != "" {
+			s.client.EnableDryRun(dryRunFile)
+		}
+	}
+	s.client.DefaultLabels = defaultLabels
+	return s.client
+}
@@ -0,0 +1,140 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/e2eterraformprovider/terraform-provider-tir/models"
+)
+
+// SSH keys are saved on the account, not in a project, so they are addressed by the active IAM
+// alone and can be attached to any node the IAM can reach.
+
+// NewSSHKey saves a public key on the account.
+func (c *Client) NewSSHKey(item *models.SSHKey, activeIAM string) (map[string]interface{}, error) {
+	jsonPayload, _ := json.Marshal(item)
+	url := c.Api_endpoint + "/ssh_keys/"
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return nil, err
+	}
+	params := req.URL.Query()
+	params.Add("apikey", c.Api_key)
+	params.Add("active_iam", activeIAM)
+	req.URL.RawQuery = params.Encode()
+	req.Header.Add("Authorization", "Bearer "+c.Auth_token)
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "terraform/e2e")
+	log.Println(RedactRequest(req))
+	response, err := c.HttpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if err := CheckResponseStatus(response); err != nil {
+		return nil, err
+	}
+	resBody, _ := io.ReadAll(response.Body)
+	var jsonRes map[string]interface{}
+	if err := json.Unmarshal(resBody, &jsonRes); err != nil {
+		return nil, err
+	}
+	return jsonRes, nil
+}
+
+// GetSSHKey returns the saved key keyID.
+func (c *Client) GetSSHKey(keyID string, activeIAM string) (map[string]interface{}, error) {
+	url := c.Api_endpoint + "/ssh_keys/" + keyID + "/"
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	params := req.URL.Query()
+	params.Add("apikey", c.Api_key)
+	params.Add("active_iam", activeIAM)
+	req.URL.RawQuery = params.Encode()
+	req.Header.Add("Authorization", "Bearer "+c.Auth_token)
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "terraform/e2e")
+	log.Println(RedactRequest(req))
+	response, err := c.HttpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	return decodeResource(response, "SSH key", keyID)
+}
+
+// DeleteSSHKey removes the saved key keyID from the account. Nodes it was attached to keep it
+// in their authorized keys.
+func (c *Client) DeleteSSHKey(keyID string, activeIAM string) error {
+	url := c.Api_endpoint + "/ssh_keys/" + keyID + "/"
+	req, err := http.NewRequest("DELETE", url, nil)
+	if err != nil {
+		return err
+	}
+	params := req.URL.Query()
+	params.Add("apikey", c.Api_key)
+	params.Add("active_iam", activeIAM)
+	req.URL.RawQuery = params.Encode()
+	req.Header.Add("Authorization", "Bearer "+c.Auth_token)
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "terraform/e2e")
+	log.Println(RedactRequest(req))
+	response, err := c.HttpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode == http.StatusNotFound {
+		return &NotFoundError{Kind: "SSH key", ID: keyID}
+	}
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		body, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("got a non 2xx status code: %v - %s", response.StatusCode, string(body))
+	}
+	return nil
+}
+
+// ListSSHKeys returns every key saved on the account.
+func (c *Client) ListSSHKeys(activeIAM string) ([]interface{}, error) {
+	return c.getCollection(c.Api_endpoint+"/ssh_keys/", activeIAM)
+}
+
+// UpdateNodeSSHKeys attaches (attach true) or detaches the saved keys keyIDs to or from the
+// authorized keys of the node nodeID.
+func (c *Client) UpdateNodeSSHKeys(nodeID string, projectID string, teamID string, activeIAM string, keyIDs []int, attach bool) error {
+	action := "detach_ssh_keys"
+	if attach {
+		action = "attach_ssh_keys"
+	}
+	jsonPayload, _ := json.Marshal(map[string]interface{}{"ssh_key_ids": keyIDs})
+	urlNode := c.Api_endpoint + "/teams/" + teamID + "/projects/" + projectID + "/notebooks/" + nodeID + "/actions/"
+	req, err := http.NewRequest("PUT", urlNode, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return err
+	}
+	params := req.URL.Query()
+	params.Add("apikey", c.Api_key)
+	params.Add("active_iam", activeIAM)
+	params.Add("action", action)
+	req.URL.RawQuery = params.Encode()
+	req.Header.Add("Authorization", "Bearer "+c.Auth_token)
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "terraform/e2e")
+	log.Println(RedactRequest(req))
+	response, err := c.HttpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("got a non 200 status code: %v - %s", response.StatusCode, string(body))
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package constants
 
 import (
 	"log"
+	"sort"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 )
@@ -206,6 +207,29 @@ func GetFrameworkName(framework string) (string, diag.Diagnostics) {
 	return frameName, nil
 }
 
+// GetFrameworkNames returns every framework accepted by GetFrameworkName.
+func GetFrameworkNames() []string {
+	names := make([]string, 0, len(frameworkMap))
+	for name := range frameworkMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetServerVersions returns the server versions with a dedicated container for the framework.
+// Frameworks that pick their container by model selection return an empty list.
+func GetServerVersions(framework string) []string {
+	var versions []string
+	for version := range FrameworkContainerNames[framework] {
+		if version == "MODEL_SELECTED" || version == "MODEL_NOT_SELECTED" {
+			continue
+		}
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+	return versions
+}
 
 func GetDefaultHuggingFaceID(framework string) string {
     switch framework {
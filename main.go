@@ -1,18 +1,55 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"log"
+
+	"github.com/e2eterraformprovider/terraform-provider-tir/client"
 	"github.com/e2eterraformprovider/terraform-provider-tir/tir"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/fwprovider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
+	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
+	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
 )
 
 func main() {
 	log.SetFlags(log.Flags() &^ (log.Ldate | log.Ltime))
+	ctx := context.Background()
+
+	var debug bool
+	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
+	flag.Parse()
 
-	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: func() *schema.Provider {
-			return e2e.Provider()
+	// Both halves of the provider are configured from the same provider block and share the
+	// API client built from it.
+	sharedClient := client.NewSharedClient()
+
+	// The SDKv2 provider speaks protocol 5, upgrade it so it can be muxed with the
+	// plugin framework provider that hosts the provider functions.
+	upgradedSdkServer, err := tf5to6server.UpgradeServer(ctx, e2e.NewProvider(sharedClient).GRPCProvider)
+	if err != nil {
+		log.Fatal(err)
+	}
+	providers := []func() tfprotov6.ProviderServer{
+		func() tfprotov6.ProviderServer {
+			return upgradedSdkServer
 		},
-	})
+		providerserver.NewProtocol6(fwprovider.New(sharedClient)),
+	}
+	muxServer, err := tf6muxserver.NewMuxServer(ctx, providers...)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var serveOpts []tf6server.ServeOpt
+	if debug {
+		serveOpts = append(serveOpts, tf6server.WithManagedDebug())
+	}
+	err = tf6server.Serve("registry.terraform.io/e2eterraformprovider/tir", muxServer.ProviderServer, serveOpts...)
+	if err != nil {
+		log.Fatal(err)
+	}
 }
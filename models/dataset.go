@@ -6,10 +6,11 @@ type PVCDetails struct {
 }
 
 type Dataset struct {
-	Name              string      `json:"name"`
-	Encryption_Enable bool        `json:"encryption_enable"`
-	Encryption_Type   *string     `json:"encryption_type,omitempty"`
-	StorageType       string      `json:"storage_type"`
-	Pvc               *PVCDetails `json:"pvc,omitempty"`
-	BucketName        *string     `json:"bucket_name,omitempty"`
+	Name              string            `json:"name"`
+	Encryption_Enable bool              `json:"encryption_enable"`
+	Encryption_Type   *string           `json:"encryption_type,omitempty"`
+	StorageType       string            `json:"storage_type"`
+	Pvc               *PVCDetails       `json:"pvc,omitempty"`
+	BucketName        *string           `json:"bucket_name,omitempty"`
+	Labels            map[string]string `json:"labels,omitempty"`
 }
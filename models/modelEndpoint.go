@@ -112,4 +112,5 @@ type ModelEndpoint struct {
 	Category                string                `json:"category"`
 	Location                string                `json:"location"`
 	Currency                string                `json:"currency"`
+	Labels                  map[string]string     `json:"labels,omitempty"`
 }
@@ -1,10 +1,11 @@
 package models
 
 type ModelRepo struct {
-	ModelType   string `json:"model_type"`
-	Name        string `json:"name"`
-	BucketName  string `json:"bucket_name"`
-	SecretKey   string `json:"secret_key"`
-	AccessKey   string `json:"access_key"`
-	StorageType string `json:"storage_type"`
+	ModelType   string            `json:"model_type"`
+	Name        string            `json:"name"`
+	BucketName  string            `json:"bucket_name"`
+	SecretKey   string            `json:"secret_key"`
+	AccessKey   string            `json:"access_key"`
+	StorageType string            `json:"storage_type"`
+	Labels      map[string]string `json:"labels,omitempty"`
 }
@@ -1,28 +1,57 @@
 package models
 
 type NodeCreate struct {
-	Name                    string   `json:"name"`
-	ClusterType             string   `json:"cluster_type"`
-	DiskSizeInGB            int      `json:"disk_size_in_gb"`
-	EnableSSH               bool     `json:"enable_ssh"`
-	ImageType               string   `json:"image_type"`
-	ImageName               string   `json:"image_name"`
-	ImageVersion            string   `json:"image_version"`
-	InstanceType            string   `json:"instance_type"`
-	IsJupyterLabEnabled     bool     `json:"is_jupyterlab_enabled"`
-	NotebookType            string   `json:"notebook_type"`
-	NotebookURL             string   `json:"notebook_url"`
-	SfsPath                 string   `json:"sfs_path"`
-	SKUName                 string   `json:"sku_name"`
-	Location                string   `json:"location"`
-	SKUType                 string   `json:"sku_type"`
-	AddOns                  []string `json:"add_ons"`
-	DatasetIDList           []string `json:"dataset_id_list"`
-	CommittedDays           int      `json:"committed_days"`
-	Currency                string   `json:"currency"`
-	Category                string   `json:"category"`
-	CommittedInstancePolicy string   `json:"committed_instance_policy"`
-	PublicSSHKeys           []string `json:"public_key"`
+	Name                    string             `json:"name"`
+	ClusterType             string             `json:"cluster_type"`
+	DiskSizeInGB            int                `json:"disk_size_in_gb"`
+	EnableSSH               bool               `json:"enable_ssh"`
+	ImageType               string             `json:"image_type"`
+	ImageName               string             `json:"image_name"`
+	ImageVersion            string             `json:"image_version"`
+	InstanceType            string             `json:"instance_type"`
+	IsJupyterLabEnabled     bool               `json:"is_jupyterlab_enabled"`
+	NotebookType            string             `json:"notebook_type"`
+	NotebookURL             string             `json:"notebook_url"`
+	SfsPath                 string             `json:"sfs_path"`
+	SKUName                 string             `json:"sku_name"`
+	Location                string             `json:"location"`
+	SKUType                 string             `json:"sku_type"`
+	AddOns                  []string           `json:"add_ons"`
+	DatasetIDList           []string           `json:"dataset_id_list"`
+	CommittedDays           int                `json:"committed_days"`
+	Currency                string             `json:"currency"`
+	Category                string             `json:"category"`
+	CommittedInstancePolicy string             `json:"committed_instance_policy"`
+	PublicSSHKeys           []string           `json:"public_key"`
+	SSHKeyIDs               []int              `json:"ssh_key_ids,omitempty"`
+	DatasetMounts           []NodeDatasetMount `json:"dataset_mounts,omitempty"`
+	CustomImage             *NodeCustomImage   `json:"custom_image,omitempty"`
+	AutoShutdown            *NodeAutoShutdown  `json:"auto_shutdown,omitempty"`
+	Labels                  map[string]string  `json:"labels,omitempty"`
+}
+
+// NodeDatasetMount mounts the dataset DatasetID at MountPath inside a node.
+type NodeDatasetMount struct {
+	DatasetID int    `json:"dataset_id"`
+	MountPath string `json:"mount_path"`
+	ReadOnly  bool   `json:"read_only"`
+}
+
+// NodeCustomImage is a container image of the user's own, used when the image type is custom.
+type NodeCustomImage struct {
+	ImageURL              string `json:"image_url"`
+	RegistryNamespaceID   int    `json:"registry_namespace_id,omitempty"`
+	RegistryIntegrationID int    `json:"registry_integration_id,omitempty"`
+	StartupCommand        string `json:"startup_command,omitempty"`
+}
+
+// NodeAutoShutdown stops a node once it has been idle for IdleMinutes and on the
+// ScheduleStopCron schedule, and starts it on the ScheduleStartCron schedule, in Timezone.
+type NodeAutoShutdown struct {
+	IdleMinutes       int    `json:"idle_minutes,omitempty"`
+	ScheduleStopCron  string `json:"schedule_stop_cron,omitempty"`
+	ScheduleStartCron string `json:"schedule_start_cron,omitempty"`
+	Timezone          string `json:"timezone"`
 }
 
 // type Notebook struct {
@@ -56,8 +85,16 @@ type NodeAction struct {
 }
 
 type ImageDetail struct {
-	ImageName           string `json:"image_name"`
-	ImageVersion        string `json:"image_version"`
-	IsJupyterLabEnabled bool   `json:"is_jupyterlab_enabled"`
-	ImageType           string `json:"image_type"`
+	ImageName           string           `json:"image_name"`
+	ImageVersion        string           `json:"image_version"`
+	IsJupyterLabEnabled bool             `json:"is_jupyterlab_enabled"`
+	ImageType           string           `json:"image_type"`
+	CustomImage         *NodeCustomImage `json:"custom_image,omitempty"`
+}
+
+// NodeImage saves the container of a node as a custom image.
+type NodeImage struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
 }
@@ -12,4 +12,5 @@ type PrivateCluster struct {
 	Location string `json:"location"`
 	Currency string `json:"currency"`
 	Category string `json:"category"`
+	Labels map[string]string `json:"labels,omitempty"`
 }
\ No newline at end of file
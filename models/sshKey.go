@@ -0,0 +1,6 @@
+package models
+
+type SSHKey struct {
+	Label  string `json:"label"`
+	SSHKey string `json:"ssh_key"`
+}
@@ -7,8 +7,11 @@ import (
 	"strconv"
 	"strings"
 	"github.com/e2eterraformprovider/terraform-provider-tir/client"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/naming"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/notfound"
 	"github.com/e2eterraformprovider/terraform-provider-tir/models"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -17,33 +20,60 @@ func ResourceModelRepo() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"name": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"name", "name_prefix"},
+				Description:  "The name of the hugging face integration token. Either name or name_prefix must be set.",
 			},
+			"name_prefix": naming.PrefixSchema("name", "integration"),
 			"integration_type": {
 				Type:     schema.TypeString,
 				Optional: true,
+				ForceNew: true,
 				Default: "hugging_face",
 			},
 			"hugging_face_token": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"hugging_face_token", "hugging_face_token_wo"},
+				Description:  "The Hugging Face access token. It is stored in state, prefer hugging_face_token_wo on Terraform 1.11 and later. Changing it, or switching to hugging_face_token_wo, recreates the integration.",
+			},
+			"hugging_face_token_wo": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				WriteOnly:    true,
+				ExactlyOneOf: []string{"hugging_face_token", "hugging_face_token_wo"},
+				Description:  "Write-only Hugging Face access token. It is sent to TIR but never stored in the plan or state. Requires Terraform 1.11 or later.",
+			},
+			"hugging_face_token_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"hugging_face_token_wo"},
+				Description:  "Version of hugging_face_token_wo. Changing it recreates the integration with the current token, as write-only values do not produce a diff on their own.",
 			},
 			"project_id": {
 				Type:     schema.TypeString,
 				Required: true,
+				ForceNew: true,
 			},
 			"active_iam": {
 				Type:     schema.TypeString,
 				Required: true,
+				ForceNew: true,
 			},
 			"team_id": {
 				Type:     schema.TypeString,
 				Required: true,
+				ForceNew: true,
 			},
 		},
 		CreateContext: resourceCreateIntegration,
-		UpdateContext: resourceUpdateIntegration,
 		ReadContext:   resourceReadIntegration,
 		DeleteContext: resourceDeleteIntegration,
 	}
@@ -51,9 +81,20 @@ func ResourceModelRepo() *schema.Resource {
 
 func resourceCreateIntegration(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	apiClient := m.(*client.Client)
+	if _, err := naming.Resolve(d, "name"); err != nil {
+		return diag.FromErr(err)
+	}
+	token := d.Get("hugging_face_token").(string)
+	tokenWO, diags := d.GetRawConfigAt(cty.GetAttrPath("hugging_face_token_wo"))
+	if diags.HasError() {
+		return diags
+	}
+	if !tokenWO.IsNull() && tokenWO.IsKnown() {
+		token = tokenWO.AsString()
+	}
 	payload := models.Integration{
 		IntegrationDetails: map[string]interface{}{
-			"hugging_face_token": d.Get("hugging_face_token").(string),
+			"hugging_face_token": token,
 		},
 		IntegrationType: d.Get("integration_type").(string),
 		Name:            d.Get("name").(string),
@@ -70,10 +111,23 @@ func resourceCreateIntegration(ctx context.Context, d *schema.ResourceData, m in
 }
 
 func resourceReadIntegration(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	return nil
-}
+	apiClient := m.(*client.Client)
+	integrationID := d.Id()
 
-func resourceUpdateIntegration(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	response, err := apiClient.GetIntegration(integrationID, d.Get("project_id").(string), d.Get("team_id").(string), d.Get("active_iam").(string))
+	if err != nil {
+		if client.IsNotFound(err) {
+			return notfound.Remove(d, "integration")
+		}
+		return diag.Errorf("Error finding item with id: %s - %v", integrationID, err)
+	}
+	data := response["data"].(map[string]interface{})
+	if name, ok := data["name"].(string); ok {
+		d.Set("name", name)
+	}
+	if integrationType, ok := data["integration_type"].(string); ok {
+		d.Set("integration_type", integrationType)
+	}
 	return nil
 }
 
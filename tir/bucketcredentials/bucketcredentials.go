@@ -0,0 +1,147 @@
+package bucketcredentials
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/e2eterraformprovider/terraform-provider-tir/client"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Asset describes a TIR asset backed by a bucket whose credentials are exposed as an ephemeral
+// resource.
+type Asset struct {
+	// TypeName is the suffix of the ephemeral resource type, for example "_dataset_credentials".
+	TypeName string
+	// IDAttribute is the attribute holding the ID of the asset, for example "dataset_id".
+	IDAttribute string
+	// Kind names the asset in descriptions and errors, for example "dataset".
+	Kind string
+	// ResourceType is the managed resource of the asset, for example "tir_eos".
+	ResourceType string
+	// Get reads the asset. Its data holds the bucket and access_key objects.
+	Get func(apiClient *client.Client, id string, projectID string, teamID string, activeIAM string) (map[string]interface{}, error)
+}
+
+var _ ephemeral.EphemeralResourceWithConfigure = &ephemeralResource{}
+
+// ephemeralResource reads the bucket credentials of an asset at apply time. Unlike the
+// attributes of the asset's managed resource they are never written to the plan or state.
+type ephemeralResource struct {
+	asset  Asset
+	client *client.Client
+}
+
+// NewEphemeralResource returns the credentials ephemeral resource of asset.
+func NewEphemeralResource(asset Asset) ephemeral.EphemeralResource {
+	return &ephemeralResource{asset: asset}
+}
+
+func (r *ephemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + r.asset.TypeName
+}
+
+func (r *ephemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	kind := r.asset.Kind
+	resp.Schema = schema.Schema{
+		Description: fmt.Sprintf("Fetches the bucket credentials of a TIR %s (%s) without persisting them in state or plan files.", kind, r.asset.ResourceType),
+		Attributes: map[string]schema.Attribute{
+			r.asset.IDAttribute: schema.StringAttribute{
+				Required:    true,
+				Description: fmt.Sprintf("The ID of the %s, usually %s.<name>.id.", kind, r.asset.ResourceType),
+			},
+			"project_id": schema.StringAttribute{
+				Required:    true,
+				Description: fmt.Sprintf("The ID of the project the %s belongs to.", kind),
+			},
+			"team_id": schema.StringAttribute{
+				Required:    true,
+				Description: fmt.Sprintf("The ID of the team that owns the %s.", kind),
+			},
+			"active_iam": schema.StringAttribute{
+				Required:    true,
+				Description: fmt.Sprintf("The IAM (Identity and Access Management) role used to read the %s.", kind),
+			},
+			"bucket_name": schema.StringAttribute{
+				Computed:    true,
+				Description: fmt.Sprintf("The name of the bucket backing the %s.", kind),
+			},
+			"bucket_url": schema.StringAttribute{
+				Computed:    true,
+				Description: fmt.Sprintf("The URL of the bucket backing the %s.", kind),
+			},
+			"bucket_endpoint": schema.StringAttribute{
+				Computed:    true,
+				Description: "The S3 compatible endpoint of the bucket.",
+			},
+			"access_key": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The access key of the bucket.",
+			},
+			"secret_key": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The secret key of the bucket.",
+			},
+		},
+	}
+}
+
+func (r *ephemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	apiClient, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("expected *client.Client, got %T", req.ProviderData))
+		return
+	}
+	r.client = apiClient
+}
+
+func (r *ephemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	config := map[string]types.String{}
+	for _, key := range []string{r.asset.IDAttribute, "project_id", "team_id", "active_iam"} {
+		var value types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(key), &value)...)
+		config[key] = value
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	id := config[r.asset.IDAttribute].ValueString()
+	projectID := config["project_id"].ValueString()
+	response, err := r.asset.Get(r.client, id, projectID, config["team_id"].ValueString(), config["active_iam"].ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Not able to read the %s", r.asset.Kind), err.Error())
+		return
+	}
+	data, ok := response["data"].(map[string]interface{})
+	if !ok {
+		resp.Diagnostics.AddError(fmt.Sprintf("The %s was not found", r.asset.Kind), fmt.Sprintf("%s %s does not exist in project %s", r.asset.Kind, id, projectID))
+		return
+	}
+	bucket, _ := data["bucket"].(map[string]interface{})
+	accessKey, _ := data["access_key"].(map[string]interface{})
+	config["bucket_name"] = stringValue(bucket, "bucket_name")
+	config["bucket_url"] = stringValue(bucket, "bucket_url")
+	config["bucket_endpoint"] = stringValue(bucket, "endpoint")
+	config["access_key"] = stringValue(accessKey, "access_key")
+	config["secret_key"] = stringValue(accessKey, "secret_key")
+	for key, value := range config {
+		resp.Diagnostics.Append(resp.Result.SetAttribute(ctx, path.Root(key), value)...)
+	}
+}
+
+// stringValue returns the string stored under key, or null when it is missing.
+func stringValue(values map[string]interface{}, key string) types.String {
+	value, ok := values[key].(string)
+	if !ok {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
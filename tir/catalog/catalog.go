@@ -0,0 +1,190 @@
+package catalog
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/agext/levenshtein"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// SKUPlan is a single priced plan of a SKU as returned by the gpu_service/sku/ catalog.
+type SKUPlan struct {
+	Name          string
+	SKUType       string
+	Currency      string
+	CommittedDays int
+	UnitPrice     float64
+}
+
+// ParseSKUPlans flattens the CPU and GPU sections of a SKU catalog response into a list of plans.
+func ParseSKUPlans(response map[string]interface{}) []SKUPlan {
+	var plans []SKUPlan
+	data, ok := response["data"].(map[string]interface{})
+	if !ok {
+		return plans
+	}
+	for _, section := range []string{"CPU", "GPU"} {
+		items, ok := data[section].([]interface{})
+		if !ok {
+			continue
+		}
+		for _, item := range items {
+			itemMap, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name, _ := itemMap["name"].(string)
+			planList, _ := itemMap["plans"].([]interface{})
+			for _, planItem := range planList {
+				planMap, ok := planItem.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				plan := SKUPlan{Name: name}
+				plan.SKUType, _ = planMap["sku_type"].(string)
+				plan.Currency, _ = planMap["currency"].(string)
+				if days, ok := planMap["committed_days"].(float64); ok {
+					plan.CommittedDays = int(days)
+				}
+				if price, ok := planMap["unit_price"].(float64); ok {
+					plan.UnitPrice = price
+				}
+				plans = append(plans, plan)
+			}
+		}
+	}
+	return plans
+}
+
+// FindSKUPlan returns the plan matching the given SKU name, sku_type and currency. For committed
+// plans committed_days must match as well. The returned error lists the closest valid choices.
+func FindSKUPlan(plans []SKUPlan, skuName string, skuType string, currency string, committedDays int) (*SKUPlan, error) {
+	var names []string
+	var sameName []SKUPlan
+	for _, plan := range plans {
+		names = append(names, plan.Name)
+		if plan.Name == skuName {
+			sameName = append(sameName, plan)
+		}
+	}
+	if len(sameName) == 0 {
+		return nil, fmt.Errorf("sku_name %q is not available%s", skuName, suggestion(ClosestMatches(skuName, names, 3)))
+	}
+	var options []string
+	for i, plan := range sameName {
+		if plan.SKUType != skuType || plan.Currency != currency {
+			options = append(options, fmt.Sprintf("%s/%s", plan.SKUType, plan.Currency))
+			continue
+		}
+		if skuType == "committed" && plan.CommittedDays != committedDays {
+			options = append(options, fmt.Sprintf("%s/%s/%d days", plan.SKUType, plan.Currency, plan.CommittedDays))
+			continue
+		}
+		return &sameName[i], nil
+	}
+	wanted := fmt.Sprintf("%s/%s", skuType, currency)
+	if skuType == "committed" {
+		wanted = fmt.Sprintf("%s/%d days", wanted, committedDays)
+	}
+	return nil, fmt.Errorf("sku_name %q has no %s plan, available plans are: %s", skuName, wanted, strings.Join(unique(options), ", "))
+}
+
+// ClosestMatches returns up to n candidates ordered by edit distance to target.
+func ClosestMatches(target string, candidates []string, n int) []string {
+	candidates = unique(candidates)
+	params := levenshtein.NewParams()
+	lowerTarget := strings.ToLower(target)
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return levenshtein.Distance(lowerTarget, strings.ToLower(candidates[i]), params) <
+			levenshtein.Distance(lowerTarget, strings.ToLower(candidates[j]), params)
+	})
+	if len(candidates) > n {
+		candidates = candidates[:n]
+	}
+	return candidates
+}
+
+// suggestion formats a "did you mean" hint for an error message, or returns an empty string.
+func suggestion(matches []string) string {
+	if len(matches) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(", did you mean one of: %s?", strings.Join(matches, ", "))
+}
+
+// DidYouMean returns the error for a value that is not part of the allowed list.
+func DidYouMean(attribute string, value string, allowed []string) error {
+	return fmt.Errorf("%s %q is not available%s", attribute, value, suggestion(ClosestMatches(value, allowed, 3)))
+}
+
+func unique(values []string) []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, value := range values {
+		if seen[value] {
+			continue
+		}
+		seen[value] = true
+		result = append(result, value)
+	}
+	return result
+}
+
+// HoursPerMonth is the number of billable hours used for monthly estimates.
+const HoursPerMonth = 730
+
+// Cost is the estimated spend for a number of units of a SKU plan.
+type Cost struct {
+	Hourly         float64
+	Monthly        float64
+	CommittedTotal float64
+}
+
+// EstimateCost derives the cost of running count units of the given plan. Hourly plans are
+// priced per hour; committed plans are priced for the whole committed period.
+func EstimateCost(plan *SKUPlan, count int) Cost {
+	units := float64(count)
+	if plan.SKUType == "committed" && plan.CommittedDays > 0 {
+		total := plan.UnitPrice * units
+		hourly := total / float64(plan.CommittedDays*24)
+		return Cost{
+			Hourly:         round(hourly),
+			Monthly:        round(hourly * HoursPerMonth),
+			CommittedTotal: round(total),
+		}
+	}
+	hourly := plan.UnitPrice * units
+	return Cost{
+		Hourly:  round(hourly),
+		Monthly: round(hourly * HoursPerMonth),
+	}
+}
+
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+// SetEstimatedCost stores the estimate in the estimated_* computed attributes of the plan.
+func SetEstimatedCost(d *schema.ResourceDiff, cost Cost) error {
+	if err := d.SetNew("estimated_hourly_cost", cost.Hourly); err != nil {
+		return err
+	}
+	if err := d.SetNew("estimated_monthly_cost", cost.Monthly); err != nil {
+		return err
+	}
+	return d.SetNew("committed_total_cost", cost.CommittedTotal)
+}
+
+// SetEstimatedCostUnknown marks the estimate as known after apply, used when the SKU
+// or the unit count depends on values that are not known yet.
+func SetEstimatedCostUnknown(d *schema.ResourceDiff) error {
+	for _, key := range []string{"estimated_hourly_cost", "estimated_monthly_cost", "committed_total_cost"} {
+		if err := d.SetNewComputed(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
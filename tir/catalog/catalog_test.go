@@ -0,0 +1,269 @@
+package catalog
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func testSKUCatalog() map[string]interface{} {
+	return map[string]interface{}{
+		"data": map[string]interface{}{
+			"CPU": []interface{}{
+				map[string]interface{}{
+					"name": "C3.8GB",
+					"plans": []interface{}{
+						map[string]interface{}{"sku_type": "hourly", "currency": "INR", "unit_price": 3.1},
+						map[string]interface{}{"sku_type": "committed", "currency": "INR", "committed_days": float64(30), "unit_price": 2000.0},
+					},
+				},
+			},
+			"GPU": []interface{}{
+				map[string]interface{}{
+					"name": "GDC3.A10080",
+					"plans": []interface{}{
+						map[string]interface{}{"sku_type": "hourly", "currency": "INR", "unit_price": 220.0},
+						map[string]interface{}{"sku_type": "hourly", "currency": "USD", "unit_price": 2.6},
+					},
+				},
+				"not a sku",
+			},
+		},
+	}
+}
+
+func TestParseSKUPlans(t *testing.T) {
+	cases := []struct {
+		name     string
+		response map[string]interface{}
+		want     []SKUPlan
+	}{
+		{
+			name:     "cpu and gpu sections",
+			response: testSKUCatalog(),
+			want: []SKUPlan{
+				{Name: "C3.8GB", SKUType: "hourly", Currency: "INR", UnitPrice: 3.1},
+				{Name: "C3.8GB", SKUType: "committed", Currency: "INR", CommittedDays: 30, UnitPrice: 2000},
+				{Name: "GDC3.A10080", SKUType: "hourly", Currency: "INR", UnitPrice: 220},
+				{Name: "GDC3.A10080", SKUType: "hourly", Currency: "USD", UnitPrice: 2.6},
+			},
+		},
+		{
+			name:     "no data",
+			response: map[string]interface{}{"message": "error"},
+			want:     nil,
+		},
+		{
+			name:     "missing sections",
+			response: map[string]interface{}{"data": map[string]interface{}{}},
+			want:     nil,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ParseSKUPlans(tc.response)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("ParseSKUPlans() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFindSKUPlan(t *testing.T) {
+	plans := ParseSKUPlans(testSKUCatalog())
+	cases := []struct {
+		name          string
+		skuName       string
+		skuType       string
+		currency      string
+		committedDays int
+		want          *SKUPlan
+		wantErr       string
+	}{
+		{
+			name:     "hourly",
+			skuName:  "GDC3.A10080",
+			skuType:  "hourly",
+			currency: "USD",
+			want:     &SKUPlan{Name: "GDC3.A10080", SKUType: "hourly", Currency: "USD", UnitPrice: 2.6},
+		},
+		{
+			name:          "committed",
+			skuName:       "C3.8GB",
+			skuType:       "committed",
+			currency:      "INR",
+			committedDays: 30,
+			want:          &SKUPlan{Name: "C3.8GB", SKUType: "committed", Currency: "INR", CommittedDays: 30, UnitPrice: 2000},
+		},
+		{
+			name:     "hourly ignores committed days",
+			skuName:  "C3.8GB",
+			skuType:  "hourly",
+			currency: "INR",
+			want:     &SKUPlan{Name: "C3.8GB", SKUType: "hourly", Currency: "INR", UnitPrice: 3.1},
+		},
+		{
+			name:     "unknown name suggests the closest",
+			skuName:  "C3.8G",
+			skuType:  "hourly",
+			currency: "INR",
+			wantErr:  `sku_name "C3.8G" is not available, did you mean one of: C3.8GB, GDC3.A10080?`,
+		},
+		{
+			name:     "wrong currency lists the plans",
+			skuName:  "C3.8GB",
+			skuType:  "hourly",
+			currency: "USD",
+			wantErr:  `sku_name "C3.8GB" has no hourly/USD plan, available plans are: hourly/INR, committed/INR`,
+		},
+		{
+			name:          "wrong committed days",
+			skuName:       "C3.8GB",
+			skuType:       "committed",
+			currency:      "INR",
+			committedDays: 90,
+			wantErr:       `sku_name "C3.8GB" has no committed/INR/90 days plan, available plans are: hourly/INR, committed/INR/30 days`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := FindSKUPlan(plans, tc.skuName, tc.skuType, tc.currency, tc.committedDays)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("FindSKUPlan() error = %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FindSKUPlan() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("FindSKUPlan() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestClosestMatches(t *testing.T) {
+	cases := []struct {
+		name       string
+		target     string
+		candidates []string
+		n          int
+		want       []string
+	}{
+		{
+			name:       "ordered by distance",
+			target:     "pytorch",
+			candidates: []string{"tensorflow", "PyTorch", "pytorch-lightning"},
+			n:          3,
+			want:       []string{"PyTorch", "tensorflow", "pytorch-lightning"},
+		},
+		{
+			name:       "limited to n",
+			target:     "vllm",
+			candidates: []string{"VLLM", "SGLANG", "TRITON", "DYNAMO"},
+			n:          1,
+			want:       []string{"VLLM"},
+		},
+		{
+			name:       "duplicates removed",
+			target:     "a",
+			candidates: []string{"a", "a", "b"},
+			n:          3,
+			want:       []string{"a", "b"},
+		},
+		{
+			name:       "no candidates",
+			target:     "a",
+			candidates: nil,
+			n:          3,
+			want:       nil,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ClosestMatches(tc.target, tc.candidates, tc.n)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("ClosestMatches() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDidYouMean(t *testing.T) {
+	cases := []struct {
+		name    string
+		value   string
+		allowed []string
+		want    string
+	}{
+		{
+			name:    "with suggestions",
+			value:   "vlm",
+			allowed: []string{"VLLM", "TRITON"},
+			want:    `framework "vlm" is not available, did you mean one of: VLLM, TRITON?`,
+		},
+		{
+			name:  "nothing to suggest",
+			value: "vlm",
+			want:  `framework "vlm" is not available`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := DidYouMean("framework", tc.value, tc.allowed)
+			if err == nil || !strings.HasPrefix(err.Error(), tc.want) || len(err.Error()) != len(tc.want) {
+				t.Errorf("DidYouMean() = %v, want %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestEstimateCost(t *testing.T) {
+	cases := []struct {
+		name  string
+		plan  SKUPlan
+		count int
+		want  Cost
+	}{
+		{
+			name:  "hourly",
+			plan:  SKUPlan{SKUType: "hourly", UnitPrice: 3.1},
+			count: 2,
+			want:  Cost{Hourly: 6.2, Monthly: 4526},
+		},
+		{
+			name:  "committed is spread over the period",
+			plan:  SKUPlan{SKUType: "committed", CommittedDays: 30, UnitPrice: 2000},
+			count: 1,
+			want:  Cost{Hourly: 2.78, Monthly: 2027.78, CommittedTotal: 2000},
+		},
+		{
+			name:  "committed for several units",
+			plan:  SKUPlan{SKUType: "committed", CommittedDays: 90, UnitPrice: 5400},
+			count: 2,
+			want:  Cost{Hourly: 5, Monthly: 3650, CommittedTotal: 10800},
+		},
+		{
+			name:  "committed without days is priced per hour",
+			plan:  SKUPlan{SKUType: "committed", UnitPrice: 1.5},
+			count: 1,
+			want:  Cost{Hourly: 1.5, Monthly: 1095},
+		},
+		{
+			name:  "no units",
+			plan:  SKUPlan{SKUType: "hourly", UnitPrice: 220},
+			count: 0,
+			want:  Cost{},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := EstimateCost(&tc.plan, tc.count)
+			if got != tc.want {
+				t.Errorf("EstimateCost() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package dataset
+
+import (
+	"github.com/e2eterraformprovider/terraform-provider-tir/client"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/bucketcredentials"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+)
+
+// NewDatasetCredentialsEphemeralResource returns tir_dataset_credentials, the bucket credentials
+// of a tir_eos dataset.
+func NewDatasetCredentialsEphemeralResource() ephemeral.EphemeralResource {
+	return bucketcredentials.NewEphemeralResource(bucketcredentials.Asset{
+		TypeName:     "_dataset_credentials",
+		IDAttribute:  "dataset_id",
+		Kind:         "dataset",
+		ResourceType: "tir_eos",
+		Get:          (*client.Client).GetDataset,
+	})
+}
@@ -0,0 +1,12 @@
+package dataset
+
+import (
+	"github.com/e2eterraformprovider/terraform-provider-tir/client"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/listing"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+)
+
+// NewEOSListResource returns the tir_eos list resource used by `terraform query`.
+func NewEOSListResource() list.ListResource {
+	return listing.New("tir_eos", "EOS dataset", "name", ResourceEOS, (*client.Client).ListDatasets)
+}
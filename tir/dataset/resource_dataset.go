@@ -2,13 +2,15 @@ package dataset
 
 import (
 	"context"
-	"log"
 	"math"
 	"strconv"
-	"strings"
 	"github.com/e2eterraformprovider/terraform-provider-tir/models"
 
 	"github.com/e2eterraformprovider/terraform-provider-tir/client"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/naming"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/notfound"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/identity"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/labels"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -18,10 +20,13 @@ func ResourceEOS() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The name of the EOS (Elastic Object Storage) resource. This is a required field and must be unique.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"name", "name_prefix"},
+				Description:  "The name of the EOS (Elastic Object Storage) resource. It must be unique. Either name or name_prefix must be set.",
 			},
+			"name_prefix": naming.PrefixSchema("name", "dataset"),
 			"storage_type": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -57,11 +62,13 @@ func ResourceEOS() *schema.Resource {
 			},
 			"access_key": {
 				Type:        schema.TypeString,
+				Sensitive:   true,
 				Computed:    true,
 				Description: "The access key for the EOS resource. This is computed automatically.",
 			},
 			"secret_key": {
 				Type:        schema.TypeString,
+				Sensitive:   true,
 				Computed:    true,
 				Description: "The secret key for the EOS resource. This is computed automatically.",
 			},
@@ -70,6 +77,14 @@ func ResourceEOS() *schema.Resource {
 				Computed:    true,
 				Description: "The current status of the EOS resource. This is computed automatically.",
 			},
+			"labels":     labels.Schema("dataset"),
+			"labels_all": labels.AllSchema("dataset"),
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Prevents Terraform from deleting the dataset and its bucket data while true. Set it to false in a separate apply before destroying or replacing the dataset. Default is false.",
+			},
 			"created_at": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -107,11 +122,19 @@ func ResourceEOS() *schema.Resource {
 		UpdateContext: resourceUpdateDataset,
 		ReadContext:   resourceReadDataset,
 		DeleteContext: resourceDeleteDataset,
+		CustomizeDiff: labels.CustomizeDiff,
+		Identity:      identity.Schema(),
+		Importer: &schema.ResourceImporter{
+			StateContext: identity.ImportState,
+		},
 	}
 }
 
 func resourceCreateDataset(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
+	if _, err := naming.Resolve(d, "name"); err != nil {
+		return diag.FromErr(err)
+	}
 	var diags diag.Diagnostics
 
 	encryptionValue := ""
@@ -135,6 +158,7 @@ func resourceCreateDataset(ctx context.Context, d *schema.ResourceData, m interf
 		Name:              d.Get("name").(string),
 		Encryption_Enable: false,
 		StorageType:       storage_type,
+		Labels:            labels.Expand(d, m),
 	}
 	if d.Get("encryption_enable").(bool) {
 		dataset.Encryption_Enable = true
@@ -163,23 +187,24 @@ func resourceCreateDataset(ctx context.Context, d *schema.ResourceData, m interf
 	d.SetId(strconv.Itoa(int(math.Round(datasetId))))
 	d.Set("status", data["status"].(string))
 	d.Set("created_at", data["created_at"].(string))
+	if err := identity.Set(d); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return diags
 }
 
 func resourceReadDataset(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*client.Client)
+	apiClient := m.(*client.Client)
 	var diags diag.Diagnostics
 	datasetId := d.Id()
 
-	response, err := client.GetDataset(datasetId, d.Get("project_id").(string), d.Get("team_id").(string), d.Get("active_iam").(string))
+	response, err := apiClient.GetDataset(datasetId, d.Get("project_id").(string), d.Get("team_id").(string), d.Get("active_iam").(string))
 	if err != nil {
-		if strings.Contains(err.Error(), "404 Not Found") {
-			log.Println("[INFO] Node not found, setting ID to empty")
-			d.SetId("")
-			return nil
+		if client.IsNotFound(err) {
+			return notfound.Remove(d, "dataset")
 		}
-		return diag.Errorf("Some problem while fetching eos details")
+		return diag.Errorf("Some problem while fetching eos details: %s", err)
 	}
 	data := response["data"].(map[string]interface{})
 	bucket, ok := data["bucket"].(map[string]interface{})
@@ -198,13 +223,25 @@ func resourceReadDataset(ctx context.Context, d *schema.ResourceData, m interfac
 	}
 	d.Set("status", data["status"].(string))
 	d.Set("created_at", data["created_at"].(string))
+	if err := labels.Set(d, m, data["labels"]); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := identity.Set(d); err != nil {
+		return diag.FromErr(err)
+	}
 	return diags
 }
 
 func resourceUpdateDataset(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := labels.Update(d, m, client.DatasetCollection); err != nil {
+		return diag.Errorf("Updating labels failed: %s", err)
+	}
 	return nil
 }
 func resourceDeleteDataset(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if d.Get("deletion_protection").(bool) {
+		return diag.Errorf("Cannot delete EOS dataset %s while deletion_protection is true. Set deletion_protection to false and apply before destroying it.", d.Id())
+	}
 	var diags diag.Diagnostics
 
 	apiClient := m.(*client.Client)
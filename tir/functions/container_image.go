@@ -0,0 +1,84 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/e2eterraformprovider/terraform-provider-tir/constants"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &ContainerImageFunction{}
+
+// ContainerImageFunction resolves the container image TIR uses for a framework, the same way
+// tir_model_endpoint does when it computes container_name.
+type ContainerImageFunction struct{}
+
+func NewContainerImageFunction() function.Function {
+	return &ContainerImageFunction{}
+}
+
+func (f *ContainerImageFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "container_image"
+}
+
+func (f *ContainerImageFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Container image used for a model endpoint framework",
+		Description: "Returns the container image that tir_model_endpoint deploys for the given framework. server_version selects the image for versioned frameworks like TRITON, PYTORCH, NEMO and TENSOR_RT and must be empty for the others, where model_selected picks between the image that loads a TIR model and the one that downloads from Hugging Face.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "framework",
+				Description: "The framework of the endpoint, for example VLLM or TRITON.",
+			},
+			function.StringParameter{
+				Name:        "server_version",
+				Description: "The server version, for example v24.02. Use an empty string for frameworks without server versions.",
+			},
+			function.BoolParameter{
+				Name:        "model_selected",
+				Description: "Whether the endpoint loads a model from a TIR model repository.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *ContainerImageFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var framework, serverVersion string
+	var modelSelected bool
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &framework, &serverVersion, &modelSelected))
+	if resp.Error != nil {
+		return
+	}
+	if _, ok := constants.FrameworkContainerNames[framework]; !ok {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("unknown framework %q, supported frameworks are: %s", framework, strings.Join(containerFrameworks(), ", ")))
+		return
+	}
+	modelID := ""
+	if modelSelected {
+		modelID = "selected"
+	}
+	_, image := constants.GetContainerName(serverVersion, modelID, framework)
+	if image == "" {
+		versions := constants.GetServerVersions(framework)
+		if len(versions) == 0 {
+			resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("framework %s has no server versions, server_version must be empty", framework))
+		} else {
+			resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("unknown server_version %q for framework %s, supported versions are: %s", serverVersion, framework, strings.Join(versions, ", ")))
+		}
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, image))
+}
+
+func containerFrameworks() []string {
+	var frameworks []string
+	for _, framework := range constants.GetFrameworkNames() {
+		if _, ok := constants.FrameworkContainerNames[framework]; ok {
+			frameworks = append(frameworks, framework)
+		}
+	}
+	return frameworks
+}
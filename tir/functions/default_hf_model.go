@@ -0,0 +1,49 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/e2eterraformprovider/terraform-provider-tir/constants"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &DefaultHFModelFunction{}
+
+// DefaultHFModelFunction returns the Hugging Face model tir_model_endpoint uses by default for a framework.
+type DefaultHFModelFunction struct{}
+
+func NewDefaultHFModelFunction() function.Function {
+	return &DefaultHFModelFunction{}
+}
+
+func (f *DefaultHFModelFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "default_hf_model"
+}
+
+func (f *DefaultHFModelFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Default Hugging Face model of a model endpoint framework",
+		Description: "Returns the Hugging Face model ID that tir_model_endpoint sends as hugging_face_id for the framework. Returns an empty string for frameworks without a default model, such as VLLM, where the model must be configured explicitly.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "framework",
+				Description: "The framework of the endpoint, for example LLAMA_3_1.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *DefaultHFModelFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var framework string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &framework))
+	if resp.Error != nil {
+		return
+	}
+	if _, diags := constants.GetFrameworkName(framework); diags.HasError() {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("unknown framework %q", framework))
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, constants.GetDefaultHuggingFaceID(framework)))
+}
@@ -0,0 +1,50 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/e2eterraformprovider/terraform-provider-tir/constants"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &FrameworkSlugFunction{}
+
+// FrameworkSlugFunction maps a framework name to the identifier the TIR API expects.
+type FrameworkSlugFunction struct{}
+
+func NewFrameworkSlugFunction() function.Function {
+	return &FrameworkSlugFunction{}
+}
+
+func (f *FrameworkSlugFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "framework_slug"
+}
+
+func (f *FrameworkSlugFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "API identifier of a model endpoint framework",
+		Description: "Returns the identifier the TIR API uses for the framework, for example llama-3-8b-instruct for LLAMA_3. Fails for frameworks that tir_model_endpoint does not support.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "framework",
+				Description: "The framework of the endpoint, for example LLAMA_3.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *FrameworkSlugFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var framework string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &framework))
+	if resp.Error != nil {
+		return
+	}
+	slug, diags := constants.GetFrameworkName(framework)
+	if diags.HasError() {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("unknown framework %q", framework))
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, slug))
+}
@@ -0,0 +1,166 @@
+package fwprovider
+
+import (
+	"context"
+
+	"github.com/e2eterraformprovider/terraform-provider-tir/client"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/dataset"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/functions"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/modelEndpoint"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/modelRepo"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/notebook"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/privateCluster"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ provider.ProviderWithFunctions          = &tirProvider{}
+	_ provider.ProviderWithEphemeralResources = &tirProvider{}
+	_ provider.ProviderWithListResources      = &tirProvider{}
+	_ provider.ProviderWithActions            = &tirProvider{}
+)
+
+// tirProvider is the plugin framework half of the provider. It is served next to the SDKv2
+// provider through terraform-plugin-mux and hosts the features only the framework supports.
+type tirProvider struct {
+	shared *client.SharedClient
+}
+
+// New returns the plugin framework provider. It configures its API client through shared, the
+// same holder the SDKv2 provider uses, so both halves talk to TIR with one client.
+func New(shared *client.SharedClient) provider.Provider {
+	return &tirProvider{shared: shared}
+}
+
+func (p *tirProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "tir"
+}
+
+// Schema must stay identical to the SDKv2 provider schema in tir/provider.go, the mux server
+// rejects providers whose schemas differ.
+func (p *tirProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"api_endpoint": schema.StringAttribute{
+				Optional:    true,
+				Description: "Endpoint of e2e tir platform",
+			},
+			"auth_token": schema.StringAttribute{
+				Required:    true,
+				Sensitive:   true,
+				Description: "Authentication token",
+			},
+			"api_key": schema.StringAttribute{
+				Required:    true,
+				Sensitive:   true,
+				Description: "API Key for authentication",
+			},
+			"dry_run": schema.BoolAttribute{
+				Optional:    true,
+				Description: "When true, create, update and delete calls are not sent to TIR. They are recorded with method, path and body, secrets redacted, in dry_run_file and answered with synthetic responses so the apply completes. Read only calls are still sent. Default is false.",
+			},
+			"dry_run_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "The file dry run mode writes the recorded calls to, as a JSON array. It is rewritten on every recorded call of an apply. Default is 'tir-dry-run.json'.",
+			},
+			"default_labels": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Labels applied to every TIR resource managed by this provider. Labels set on a resource win over the same default key.",
+			},
+		},
+	}
+}
+
+// tirProviderModel maps the provider configuration.
+type tirProviderModel struct {
+	ApiEndpoint   types.String `tfsdk:"api_endpoint"`
+	AuthToken     types.String `tfsdk:"auth_token"`
+	ApiKey        types.String `tfsdk:"api_key"`
+	DefaultLabels types.Map    `tfsdk:"default_labels"`
+	DryRun        types.Bool   `tfsdk:"dry_run"`
+	DryRunFile    types.String `tfsdk:"dry_run_file"`
+}
+
+func (p *tirProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	var config tirProviderModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	apiEndpoint := client.DefaultApiEndpoint
+	if !config.ApiEndpoint.IsNull() && !config.ApiEndpoint.IsUnknown() {
+		apiEndpoint = config.ApiEndpoint.ValueString()
+	}
+	defaultLabels := make(map[string]string)
+	if !config.DefaultLabels.IsNull() && !config.DefaultLabels.IsUnknown() {
+		resp.Diagnostics.Append(config.DefaultLabels.ElementsAs(ctx, &defaultLabels, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	dryRunFile := ""
+	if config.DryRun.ValueBool() {
+		dryRunFile = client.DefaultDryRunFile
+		if !config.DryRunFile.IsNull() && !config.DryRunFile.IsUnknown() {
+			dryRunFile = config.DryRunFile.ValueString()
+		}
+	}
+	apiClient := p.shared.Configure(config.ApiKey.ValueString(), config.AuthToken.ValueString(), apiEndpoint, defaultLabels, dryRunFile)
+	resp.DataSourceData = apiClient
+	resp.ResourceData = apiClient
+	resp.EphemeralResourceData = apiClient
+	resp.ListResourceData = apiClient
+	resp.ActionData = apiClient
+}
+
+func (p *tirProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		dataset.NewDatasetCredentialsEphemeralResource,
+		modelRepo.NewModelRepositoryCredentialsEphemeralResource,
+	}
+}
+
+func (p *tirProvider) ListResources(ctx context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		notebook.NewNodeListResource,
+		modelEndpoint.NewModelEndpointListResource,
+		dataset.NewEOSListResource,
+		modelRepo.NewModelRepoListResource,
+		privateCluster.NewPrivateClusterListResource,
+	}
+}
+
+func (p *tirProvider) Actions(ctx context.Context) []func() action.Action {
+	return []func() action.Action{
+		notebook.NewNodeStopAction,
+		notebook.NewNodeStartAction,
+		notebook.NewNodeRestartAction,
+		modelEndpoint.NewModelEndpointStopAction,
+		modelEndpoint.NewModelEndpointStartAction,
+	}
+}
+
+func (p *tirProvider) Resources(ctx context.Context) []func() resource.Resource {
+	return nil
+}
+
+func (p *tirProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+func (p *tirProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		functions.NewContainerImageFunction,
+		functions.NewFrameworkSlugFunction,
+		functions.NewDefaultHFModelFunction,
+	}
+}
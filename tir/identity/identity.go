@@ -0,0 +1,93 @@
+package identity
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Attributes are the identity attributes shared by all TIR resources that live inside a project.
+// Together they address the resource in the TIR API.
+var Attributes = []string{"id", "project_id", "team_id", "active_iam"}
+
+// Schema returns the resource identity schema of project scoped TIR resources.
+func Schema() *schema.ResourceIdentity {
+	return &schema.ResourceIdentity{
+		Version: 0,
+		SchemaFunc: func() map[string]*schema.Schema {
+			return map[string]*schema.Schema{
+				"id": {
+					Type:              schema.TypeString,
+					RequiredForImport: true,
+					Description:       "The ID of the resource in TIR.",
+				},
+				"project_id": {
+					Type:              schema.TypeString,
+					RequiredForImport: true,
+					Description:       "The ID of the project the resource belongs to.",
+				},
+				"team_id": {
+					Type:              schema.TypeString,
+					RequiredForImport: true,
+					Description:       "The ID of the team the project belongs to.",
+				},
+				"active_iam": {
+					Type:              schema.TypeString,
+					RequiredForImport: true,
+					Description:       "The IAM used to reach the resource.",
+				},
+			}
+		},
+	}
+}
+
+// Set stores the identity of the resource from its ID and project attributes.
+func Set(d *schema.ResourceData) error {
+	resourceIdentity, err := d.Identity()
+	if err != nil {
+		return err
+	}
+	if err := resourceIdentity.Set("id", d.Id()); err != nil {
+		return err
+	}
+	for _, key := range Attributes[1:] {
+		if err := resourceIdentity.Set(key, d.Get(key).(string)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ImportState imports a resource either from an identity or from an ID of the form
+// <active_iam>/<team_id>/<project_id>/<id>.
+func ImportState(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	values := make(map[string]string)
+	if d.Id() != "" {
+		parts := strings.Split(d.Id(), "/")
+		if len(parts) != 4 || parts[0] == "" || parts[1] == "" || parts[2] == "" || parts[3] == "" {
+			return nil, fmt.Errorf("unexpected import ID %q, expected <active_iam>/<team_id>/<project_id>/<id>", d.Id())
+		}
+		values["active_iam"], values["team_id"], values["project_id"], values["id"] = parts[0], parts[1], parts[2], parts[3]
+	} else {
+		resourceIdentity, err := d.Identity()
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range Attributes {
+			value, ok := resourceIdentity.GetOk(key)
+			if !ok {
+				return nil, fmt.Errorf("identity is missing %s", key)
+			}
+			values[key] = value.(string)
+		}
+	}
+	d.SetId(values["id"])
+	for _, key := range Attributes[1:] {
+		if err := d.Set(key, values[key]); err != nil {
+			return nil, err
+		}
+	}
+	return []*schema.ResourceData{d}, nil
+}
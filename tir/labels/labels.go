@@ -0,0 +1,142 @@
+package labels
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/e2eterraformprovider/terraform-provider-tir/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Schema returns the labels attribute of a TIR resource. kind names the resource in the
+// description.
+func Schema(kind string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeMap,
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: fmt.Sprintf("Key/value labels attached to the %s, for example a team or cost center. They are merged with the provider default_labels, a key set here wins over the same default key.", kind),
+	}
+}
+
+// AllSchema returns the labels_all attribute holding the labels actually applied in TIR, the
+// provider default_labels merged with labels.
+func AllSchema(kind string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeMap,
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: fmt.Sprintf("All labels applied to the %s, including the ones inherited from the provider default_labels.", kind),
+	}
+}
+
+// Merge returns defaults overlaid with labels.
+func Merge(defaults map[string]string, labels map[string]interface{}) map[string]string {
+	merged := make(map[string]string, len(defaults)+len(labels))
+	for key, value := range defaults {
+		merged[key] = value
+	}
+	for key, value := range labels {
+		merged[key] = value.(string)
+	}
+	return merged
+}
+
+// Expand returns the labels to send to TIR for the resource, the provider default_labels
+// merged with its labels.
+func Expand(d *schema.ResourceData, m interface{}) map[string]string {
+	return Merge(defaults(m), d.Get("labels").(map[string]interface{}))
+}
+
+// CustomizeDiff plans labels_all from the provider default_labels and the labels of the
+// resource, so a change of either shows up in the plan.
+func CustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("labels") {
+		return d.SetNewComputed("labels_all")
+	}
+	merged := Merge(defaults(m), d.Get("labels").(map[string]interface{}))
+	if reflect.DeepEqual(toStringMap(d.Get("labels_all").(map[string]interface{})), merged) {
+		return nil
+	}
+	return d.SetNew("labels_all", toInterfaceMap(merged))
+}
+
+// Set records the labels TIR returned for the resource. Labels that only come from the
+// provider default_labels are kept out of labels, any other label found in TIR shows up there so
+// labels changed outside of Terraform are reported as drift. Nothing is recorded when the
+// response carries no labels.
+func Set(d *schema.ResourceData, m interface{}, value interface{}) error {
+	remote, ok := value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	defaultLabels := defaults(m)
+	configured := d.Get("labels").(map[string]interface{})
+	labels := make(map[string]interface{})
+	for key, remoteValue := range remote {
+		text := fmt.Sprint(remoteValue)
+		if _, ok := configured[key]; !ok {
+			if defaultValue, ok := defaultLabels[key]; ok && defaultValue == text {
+				continue
+			}
+		}
+		labels[key] = text
+	}
+	if err := d.Set("labels", labels); err != nil {
+		return err
+	}
+	return d.Set("labels_all", toInterfaceMap(toStringMap(remote)))
+}
+
+// Update sends the merged labels of the resource to TIR when they changed. collection is the
+// path of the resource collection in the project, for example "notebooks".
+func Update(d *schema.ResourceData, m interface{}, collection string) error {
+	if !d.HasChange("labels_all") && !d.HasChange("labels") {
+		return nil
+	}
+	apiClient := m.(*client.Client)
+	merged := Expand(d, m)
+	if err := apiClient.UpdateLabels(collection, d.Id(), d.Get("project_id").(string), d.Get("team_id").(string), d.Get("active_iam").(string), merged); err != nil {
+		return err
+	}
+	return d.Set("labels_all", toInterfaceMap(merged))
+}
+
+// Matches reports whether the labels of a listed TIR item contain every key/value of want.
+func Matches(value interface{}, want map[string]string) bool {
+	if len(want) == 0 {
+		return true
+	}
+	remote, _ := value.(map[string]interface{})
+	for key, wantValue := range want {
+		remoteValue, ok := remote[key]
+		if !ok || fmt.Sprint(remoteValue) != wantValue {
+			return false
+		}
+	}
+	return true
+}
+
+func defaults(m interface{}) map[string]string {
+	if apiClient, ok := m.(*client.Client); ok && apiClient != nil {
+		return apiClient.DefaultLabels
+	}
+	return nil
+}
+
+func toStringMap(value map[string]interface{}) map[string]string {
+	result := make(map[string]string, len(value))
+	for key, item := range value {
+		result[key] = fmt.Sprint(item)
+	}
+	return result
+}
+
+func toInterfaceMap(value map[string]string) map[string]interface{} {
+	result := make(map[string]interface{}, len(value))
+	for key, item := range value {
+		result[key] = item
+	}
+	return result
+}
@@ -0,0 +1,96 @@
+package labels
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMerge(t *testing.T) {
+	cases := []struct {
+		name     string
+		defaults map[string]string
+		labels   map[string]interface{}
+		want     map[string]string
+	}{
+		{
+			name:     "defaults only",
+			defaults: map[string]string{"team": "ml"},
+			want:     map[string]string{"team": "ml"},
+		},
+		{
+			name:   "labels only",
+			labels: map[string]interface{}{"env": "dev"},
+			want:   map[string]string{"env": "dev"},
+		},
+		{
+			name:     "label wins over default",
+			defaults: map[string]string{"team": "ml", "cost_center": "42"},
+			labels:   map[string]interface{}{"team": "research", "env": "dev"},
+			want:     map[string]string{"team": "research", "cost_center": "42", "env": "dev"},
+		},
+		{
+			name: "nothing",
+			want: map[string]string{},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Merge(tc.defaults, tc.labels)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Merge() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMatches(t *testing.T) {
+	cases := []struct {
+		name  string
+		value interface{}
+		want  map[string]string
+		match bool
+	}{
+		{
+			name:  "no filter",
+			value: nil,
+			match: true,
+		},
+		{
+			name:  "all wanted labels present",
+			value: map[string]interface{}{"team": "ml", "env": "dev"},
+			want:  map[string]string{"team": "ml"},
+			match: true,
+		},
+		{
+			name:  "different value",
+			value: map[string]interface{}{"team": "ml"},
+			want:  map[string]string{"team": "research"},
+			match: false,
+		},
+		{
+			name:  "missing key",
+			value: map[string]interface{}{"env": "dev"},
+			want:  map[string]string{"team": "ml"},
+			match: false,
+		},
+		{
+			name:  "non string value",
+			value: map[string]interface{}{"replicas": float64(2)},
+			want:  map[string]string{"replicas": "2"},
+			match: true,
+		},
+		{
+			name:  "item without labels",
+			value: nil,
+			want:  map[string]string{"team": "ml"},
+			match: false,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Matches(tc.value, tc.want); got != tc.match {
+				t.Errorf("Matches() = %t, want %t", got, tc.match)
+			}
+		})
+	}
+}
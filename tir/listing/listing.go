@@ -0,0 +1,248 @@
+package listing
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/e2eterraformprovider/terraform-provider-tir/client"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/labels"
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var (
+	_ list.ListResourceWithConfigure    = &ListResource{}
+	_ list.ListResourceWithRawV6Schemas = &ListResource{}
+)
+
+// FetchFunc returns the raw items of a TIR collection inside a project.
+type FetchFunc func(c *client.Client, projectID string, teamID string, activeIAM string) ([]interface{}, error)
+
+// ListResource lists the TIR assets backing an SDKv2 managed resource so `terraform query` can
+// discover them. The managed resource is defined with SDKv2, so its schemas are handed to the
+// framework through RawV6Schemas.
+type ListResource struct {
+	typeName      string
+	kind          string
+	nameAttribute string
+	resource      func() *schema.Resource
+	fetch         FetchFunc
+	client        *client.Client
+}
+
+// New returns a list resource for typeName. kind names the listed asset in descriptions,
+// nameAttribute is the attribute of the managed resource that holds the asset name.
+func New(typeName string, kind string, nameAttribute string, resource func() *schema.Resource, fetch FetchFunc) list.ListResource {
+	return &ListResource{
+		typeName:      typeName,
+		kind:          kind,
+		nameAttribute: nameAttribute,
+		resource:      resource,
+		fetch:         fetch,
+	}
+}
+
+// config maps the list block configuration, which is the same for every TIR list resource.
+type config struct {
+	ProjectID types.String `tfsdk:"project_id"`
+	TeamID    types.String `tfsdk:"team_id"`
+	ActiveIAM types.String `tfsdk:"active_iam"`
+	NameRegex types.String `tfsdk:"name_regex"`
+	Status    types.String `tfsdk:"status"`
+	Labels    types.Map    `tfsdk:"labels"`
+}
+
+func (l *ListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = l.typeName
+}
+
+func (l *ListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		Description: fmt.Sprintf("Lists every %s of a TIR project, so existing ones can be imported.", l.kind),
+		Attributes: map[string]listschema.Attribute{
+			"project_id": listschema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the project to list from.",
+			},
+			"team_id": listschema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the team the project belongs to.",
+			},
+			"active_iam": listschema.StringAttribute{
+				Required:    true,
+				Description: "The IAM used to access the project.",
+			},
+			"name_regex": listschema.StringAttribute{
+				Optional:    true,
+				Description: fmt.Sprintf("A regular expression the %s name must match.", l.kind),
+			},
+			"status": listschema.StringAttribute{
+				Optional:    true,
+				Description: fmt.Sprintf("The status the %s must be in, for example 'running' or 'stopped'. The comparison ignores case.", l.kind),
+			},
+			"labels": listschema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: fmt.Sprintf("Labels the %s must carry. Only the ones having every given key with the given value are listed.", l.kind),
+			},
+		},
+	}
+}
+
+func (l *ListResource) RawV6Schemas(ctx context.Context, req list.RawV6SchemaRequest, resp *list.RawV6SchemaResponse) {
+	managed := l.resource()
+	resp.ProtoV6Schema = toV6Schema(managed.ProtoSchema(ctx)())
+	resp.ProtoV6IdentitySchema = toV6IdentitySchema(managed.ProtoIdentitySchema(ctx)())
+}
+
+func (l *ListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	apiClient, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("Expected *client.Client, got %T.", req.ProviderData))
+		return
+	}
+	l.client = apiClient
+}
+
+func (l *ListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var cfg config
+	var diags diag.Diagnostics
+	diags.Append(req.Config.Get(ctx, &cfg)...)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+	var nameRegex *regexp.Regexp
+	if !cfg.NameRegex.IsNull() {
+		var err error
+		nameRegex, err = regexp.Compile(cfg.NameRegex.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("name_regex"), "Invalid name_regex", err.Error())
+			stream.Results = list.ListResultsStreamDiagnostics(diags)
+			return
+		}
+	}
+	wantLabels := make(map[string]string)
+	if !cfg.Labels.IsNull() {
+		diags.Append(cfg.Labels.ElementsAs(ctx, &wantLabels, false)...)
+		if diags.HasError() {
+			stream.Results = list.ListResultsStreamDiagnostics(diags)
+			return
+		}
+	}
+	projectID, teamID, activeIAM := cfg.ProjectID.ValueString(), cfg.TeamID.ValueString(), cfg.ActiveIAM.ValueString()
+	items, err := l.fetch(l.client, projectID, teamID, activeIAM)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Unable to list %s", l.typeName), err.Error())
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		var count int64
+		for _, item := range items {
+			itemMap, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			id, name, status := itemID(itemMap["id"]), stringValue(itemMap["name"]), stringValue(itemMap["status"])
+			if id == "" {
+				continue
+			}
+			if nameRegex != nil && !nameRegex.MatchString(name) {
+				continue
+			}
+			if !cfg.Status.IsNull() && !strings.EqualFold(cfg.Status.ValueString(), status) {
+				continue
+			}
+			if !labels.Matches(itemMap["labels"], wantLabels) {
+				continue
+			}
+			if req.Limit > 0 && count >= req.Limit {
+				return
+			}
+			result := req.NewListResult(ctx)
+			result.DisplayName = name
+			identityValues := map[string]string{"id": id, "project_id": projectID, "team_id": teamID, "active_iam": activeIAM}
+			for key, value := range identityValues {
+				result.Diagnostics.Append(result.Identity.SetAttribute(ctx, path.Root(key), types.StringValue(value))...)
+			}
+			if req.IncludeResource && !result.Diagnostics.HasError() {
+				l.readResource(ctx, req, &result, identityValues, name)
+			}
+			count++
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
+
+// readResource fills the result with the state the managed resource reads for the asset, so
+// the listed assets can be turned into configuration.
+func (l *ListResource) readResource(ctx context.Context, req list.ListRequest, result *list.ListResult, identityValues map[string]string, name string) {
+	managed := l.resource()
+	d := managed.Data(nil)
+	d.SetId(identityValues["id"])
+	for _, key := range []string{"project_id", "team_id", "active_iam"} {
+		d.Set(key, identityValues[key])
+	}
+	d.Set(l.nameAttribute, name)
+	if sdkDiags := managed.ReadContext(ctx, d, l.client); sdkDiags.HasError() {
+		for _, sdkDiag := range sdkDiags {
+			result.Diagnostics.AddError(sdkDiag.Summary, sdkDiag.Detail)
+		}
+		return
+	}
+	if d.Id() == "" {
+		result.Diagnostics.AddWarning("Listed resource no longer exists", fmt.Sprintf("The %s %q was deleted while it was being listed.", l.kind, name))
+		return
+	}
+	stateType := managed.CoreConfigSchema().ImpliedType()
+	stateValue, err := d.State().AttrsAsObjectValue(stateType)
+	if err != nil {
+		result.Diagnostics.AddError("Unable to convert the resource state", err.Error())
+		return
+	}
+	stateJSON, err := ctyjson.Marshal(stateValue, stateType)
+	if err != nil {
+		result.Diagnostics.AddError("Unable to convert the resource state", err.Error())
+		return
+	}
+	raw, err := (&tfprotov6.DynamicValue{JSON: stateJSON}).Unmarshal(req.ResourceSchema.Type().TerraformType(ctx))
+	if err != nil {
+		result.Diagnostics.AddError("Unable to convert the resource state", err.Error())
+		return
+	}
+	result.Resource.Raw = raw
+}
+
+func itemID(value interface{}) string {
+	switch id := value.(type) {
+	case float64:
+		return strconv.Itoa(int(id))
+	case string:
+		return id
+	}
+	return ""
+}
+
+func stringValue(value interface{}) string {
+	if text, ok := value.(string); ok {
+		return text
+	}
+	return ""
+}
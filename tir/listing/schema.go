@@ -0,0 +1,75 @@
+package listing
+
+import (
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// The SDKv2 resources describe themselves in protocol 5 while the framework provider is served
+// over protocol 6. The two schema representations only differ in their package, so they are
+// converted field by field.
+
+func toV6Schema(s *tfprotov5.Schema) *tfprotov6.Schema {
+	if s == nil {
+		return nil
+	}
+	return &tfprotov6.Schema{
+		Version: s.Version,
+		Block:   toV6Block(s.Block),
+	}
+}
+
+func toV6Block(b *tfprotov5.SchemaBlock) *tfprotov6.SchemaBlock {
+	if b == nil {
+		return nil
+	}
+	block := &tfprotov6.SchemaBlock{
+		Version:            b.Version,
+		Description:        b.Description,
+		DescriptionKind:    tfprotov6.StringKind(b.DescriptionKind),
+		Deprecated:         b.Deprecated,
+		DeprecationMessage: b.DeprecationMessage,
+	}
+	for _, attribute := range b.Attributes {
+		block.Attributes = append(block.Attributes, &tfprotov6.SchemaAttribute{
+			Name:               attribute.Name,
+			Type:               attribute.Type,
+			Description:        attribute.Description,
+			Required:           attribute.Required,
+			Optional:           attribute.Optional,
+			Computed:           attribute.Computed,
+			Sensitive:          attribute.Sensitive,
+			DescriptionKind:    tfprotov6.StringKind(attribute.DescriptionKind),
+			Deprecated:         attribute.Deprecated,
+			WriteOnly:          attribute.WriteOnly,
+			DeprecationMessage: attribute.DeprecationMessage,
+		})
+	}
+	for _, nested := range b.BlockTypes {
+		block.BlockTypes = append(block.BlockTypes, &tfprotov6.SchemaNestedBlock{
+			TypeName: nested.TypeName,
+			Block:    toV6Block(nested.Block),
+			Nesting:  tfprotov6.SchemaNestedBlockNestingMode(nested.Nesting),
+			MinItems: nested.MinItems,
+			MaxItems: nested.MaxItems,
+		})
+	}
+	return block
+}
+
+func toV6IdentitySchema(s *tfprotov5.ResourceIdentitySchema) *tfprotov6.ResourceIdentitySchema {
+	if s == nil {
+		return nil
+	}
+	identitySchema := &tfprotov6.ResourceIdentitySchema{Version: s.Version}
+	for _, attribute := range s.IdentityAttributes {
+		identitySchema.IdentityAttributes = append(identitySchema.IdentityAttributes, &tfprotov6.ResourceIdentitySchemaAttribute{
+			Name:              attribute.Name,
+			Type:              attribute.Type,
+			RequiredForImport: attribute.RequiredForImport,
+			OptionalForImport: attribute.OptionalForImport,
+			Description:       attribute.Description,
+		})
+	}
+	return identitySchema
+}
@@ -0,0 +1,116 @@
+package modelEndpoint
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/e2eterraformprovider/terraform-provider-tir/client"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ action.ActionWithConfigure = &endpointAction{}
+
+// defaultEndpointActionTimeout bounds how long an action waits for the endpoint to settle.
+const defaultEndpointActionTimeout = 30 * time.Minute
+
+// endpointAction starts or stops inference on a model endpoint and waits until the endpoint has
+// reached the resulting status. It replaces toggling stop_inference for one-off operations.
+type endpointAction struct {
+	operation string
+	client    *client.Client
+}
+
+// NewModelEndpointStopAction returns the tir_model_endpoint_stop action.
+func NewModelEndpointStopAction() action.Action {
+	return &endpointAction{operation: "stop"}
+}
+
+// NewModelEndpointStartAction returns the tir_model_endpoint_start action.
+func NewModelEndpointStartAction() action.Action {
+	return &endpointAction{operation: "start"}
+}
+
+// endpointActionModel maps the action configuration.
+type endpointActionModel struct {
+	EndpointID     types.String `tfsdk:"endpoint_id"`
+	ProjectID      types.String `tfsdk:"project_id"`
+	TeamID         types.String `tfsdk:"team_id"`
+	ActiveIAM      types.String `tfsdk:"active_iam"`
+	TimeoutMinutes types.Int64  `tfsdk:"timeout_minutes"`
+}
+
+func (a *endpointAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = "tir_model_endpoint_" + a.operation
+}
+
+func (a *endpointAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: fmt.Sprintf("Requests a %s of inference on a TIR model endpoint and waits until the endpoint has settled.", a.operation),
+		Attributes: map[string]schema.Attribute{
+			"endpoint_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the endpoint, usually tir_model_endpoint.<name>.id.",
+			},
+			"project_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the project where the endpoint is deployed.",
+			},
+			"team_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the team that owns the endpoint.",
+			},
+			"active_iam": schema.StringAttribute{
+				Required:    true,
+				Description: "The IAM (Identity and Access Management) role associated with the endpoint.",
+			},
+			"timeout_minutes": schema.Int64Attribute{
+				Optional:    true,
+				Description: "How long to wait for the endpoint to settle. Default is 30 minutes.",
+			},
+		},
+	}
+}
+
+func (a *endpointAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	apiClient, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("Expected *client.Client, got %T.", req.ProviderData))
+		return
+	}
+	a.client = apiClient
+}
+
+func (a *endpointAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var config endpointActionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout := defaultEndpointActionTimeout
+	if !config.TimeoutMinutes.IsNull() {
+		timeout = time.Duration(config.TimeoutMinutes.ValueInt64()) * time.Minute
+	}
+	endpointID := config.EndpointID.ValueString()
+	projectID, teamID, activeIAM := config.ProjectID.ValueString(), config.TeamID.ValueString(), config.ActiveIAM.ValueString()
+
+	verb, target := "Starting", "running"
+	if a.operation == "stop" {
+		verb, target = "Stopping", "stopped"
+	}
+	resp.SendProgress(action.InvokeProgressEvent{Message: fmt.Sprintf("%s inference on endpoint %s", verb, endpointID)})
+	if _, err := a.client.UpdateStartStopInference(endpointID, projectID, teamID, activeIAM, a.operation); err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to %s endpoint %s", a.operation, endpointID), err.Error())
+		return
+	}
+	if err := waitForEndpointStatus(ctx, a.client, endpointID, projectID, teamID, activeIAM, target, timeout); err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to %s endpoint %s", a.operation, endpointID), err.Error())
+		return
+	}
+	resp.SendProgress(action.InvokeProgressEvent{Message: fmt.Sprintf("Endpoint %s is %s", endpointID, target)})
+}
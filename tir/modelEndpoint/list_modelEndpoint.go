@@ -0,0 +1,12 @@
+package modelEndpoint
+
+import (
+	"github.com/e2eterraformprovider/terraform-provider-tir/client"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/listing"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+)
+
+// NewModelEndpointListResource returns the tir_model_endpoint list resource used by `terraform query`.
+func NewModelEndpointListResource() list.ListResource {
+	return listing.New("tir_model_endpoint", "model endpoint", "name", ResourceModel, (*client.Client).ListEndpoints)
+}
@@ -0,0 +1,629 @@
+package modelEndpoint
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// modelEndpointStateUpgraders returns the upgraders that bring older tir_model_endpoint state to the
+// current SchemaVersion.
+func modelEndpointStateUpgraders() []schema.StateUpgrader {
+	return []schema.StateUpgrader{
+		{
+			Version: 0,
+			Type:    resourceModelEndpointV0().CoreConfigSchema().ImpliedType(),
+			Upgrade: upgradeModelEndpointStateV0,
+		},
+	}
+}
+
+// upgradeModelEndpointStateV0 normalises stop_inference, which version 0 accepted as a free string,
+// to one of "start" or "stop". Values that are neither are derived from the endpoint status.
+func upgradeModelEndpointStateV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+	action, _ := rawState["stop_inference"].(string)
+	action = strings.ToLower(strings.TrimSpace(action))
+	if action != "start" && action != "stop" {
+		action = "start"
+		if rawState["status"] == "stopped" {
+			action = "stop"
+		}
+	}
+	log.Printf("[INFO] Upgrading tir_model_endpoint state to version 1, stop_inference is %q", action)
+	rawState["stop_inference"] = action
+	return rawState, nil
+}
+
+// resourceModelEndpointV0 is the tir_model_endpoint schema at version 0. It only decodes old
+// state and must not change when the resource schema does.
+func resourceModelEndpointV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the resource. This is a required field and must be unique within the project.",
+			},
+			"server_options": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "Specifies the server options for the resource. This is typically used for server types like TRITON, PYTORCH, NEMO, and TENSOR RT.",
+			},
+			"sku_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The SKU (Stock Keeping Unit) name for the resource. This defines the type of resource being deployed.",
+			},
+			"sku_type": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The SKU type for the resource. This defines the category or classification of the SKU.",
+			},
+			"committed_instance_policy": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "The policy for committed instances. This defines how committed instances are managed and billed.",
+			},
+			"committed_days": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "The number of days the instance is committed for. This is used for billing and resource allocation.",
+			},
+			"model_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "The path to the model file or directory. This is used to specify the location of the model to be deployed.",
+			},
+			"framework": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The framework used for the model. This could be TensorFlow, PyTorch, etc.",
+			},
+			"model_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "The unique identifier for the model. This is used to reference the model in the system.",
+			},
+			"model_load_integration_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "The integration ID used for loading the model. This is typically used for custom model loading workflows.",
+			},
+			"cluster_type": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The type of cluster the resource is deployed on. ",
+			},
+			"storage_type": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The type of storage used for the resource.",
+			},
+			"disk_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "/mnt/models",
+				Description: "The path where the disk is mounted. This is used to specify the location for model storage.",
+			},
+			"sfs_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "/shared/.cache",
+				Description: "The path for shared file storage. This is used for caching and shared resources.",
+			},
+			"sfs_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "The ID of the shared file storage. This is used to reference the shared storage resource.",
+			},
+			"image_pull_policy": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "Always",
+				ValidateFunc: validation.StringInSlice([]string{
+					"Always",
+					"IfNotPresent",
+				}, false),
+				Description: "The policy for pulling container images. Options are 'Always' or 'IfNotPresent'.",
+			},
+			"is_auto_scale_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Indicates whether auto-scaling is enabled for the resource.",
+			},
+			"auto_scale_policy": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Default:     nil,
+				Description: "The policy for auto-scaling the resource. This includes min/max replicas and scaling rules.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"min_replicas": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     1,
+							Description: "The minimum number of replicas to maintain during auto-scaling.",
+						},
+						"max_replicas": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     1,
+							Description: "The maximum number of replicas to scale up to during auto-scaling.",
+						},
+						"rules": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Default:     nil,
+							Description: "The rules for auto-scaling based on metrics and conditions.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"metric": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Default:     "",
+										Description: "The metric to monitor for auto-scaling",
+									},
+									"value": {
+										Type:        schema.TypeInt,
+										Optional:    true,
+										Default:     10,
+										Description: "The threshold value for the metric to trigger scaling.",
+									},
+									"condition_type": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Default:     "limit",
+										Description: "The type of condition to apply for scaling.",
+									},
+									"watch_period": {
+										Type:        schema.TypeInt,
+										Optional:    true,
+										Default:     60,
+										Description: "The period (in seconds) to watch the metric before scaling.",
+									},
+									"granularity": {
+										Type:        schema.TypeInt,
+										Optional:    true,
+										Default:     1,
+										Description: "The granularity of the metric data collection.",
+									},
+									"window": {
+										Type:        schema.TypeInt,
+										Optional:    true,
+										Default:     1,
+										Description: "The time window (in seconds) for evaluating the metric.",
+									},
+									"custom_metric_name": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Default:     "",
+										Description: "The name of a custom metric to use for scaling.",
+									},
+								},
+							},
+						},
+						"stability_period": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     300,
+							Description: "The period (in seconds) to wait after scaling before scaling again.",
+						},
+					},
+				},
+			},
+			"replica": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     1,
+				Description: "The number of replicas to deploy for the resource.",
+			},
+			"committed_replicas": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "The number of replicas that are committed for the resource.",
+			},
+			"detailed_info": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Default:     nil,
+				Description: "Detailed information about the resource, including commands, args, and logging settings.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"commands": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "",
+							Description: "Commands to execute when the resource is deployed.",
+						},
+						"args": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "",
+							Description: "Arguments to pass to the commands when the resource is deployed.",
+						},
+						"hugging_face_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "",
+							Description: "The Hugging Face model ID associated with the resource.",
+						},
+						"tokenizer": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "",
+							Description: "The tokenizer to use for the model.",
+						},
+						"server_version": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "",
+							Description: "The version of the server being used.",
+						},
+						"world_size": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     1,
+							Description: "The world size for distributed training or inference.",
+						},
+						"error_log": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Enable or disable error logging.",
+						},
+						"info_log": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Enable or disable info logging.",
+						},
+						"warning_log": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Enable or disable warning logging.",
+						},
+						"log_verbose_level": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     1,
+							Description: "The verbosity level for logging.",
+						},
+						"model_serve_type": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "",
+							Description: "The type of model serving (e.g., real-time, batch).",
+						},
+						"engine_args": {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Description: "Additional engine-specific arguments for the model.",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			"is_readiness_probe_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Enable or disable the readiness probe for the resource.",
+			},
+			"is_liveness_probe_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Enable or disable the liveness probe for the resource.",
+			},
+			"readiness_probe": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Default:     nil,
+				Description: "Configuration for the readiness probe.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"protocol": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "http",
+							Description: "The protocol to use for the readiness probe (e.g., http, tcp).",
+						},
+						"initial_delay_seconds": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     10,
+							Description: "The initial delay (in seconds) before the readiness probe starts.",
+						},
+						"success_threshold": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     1,
+							Description: "The number of successful probes required to mark the resource as ready.",
+						},
+						"failure_threshold": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     3,
+							Description: "The number of failed probes before the resource is marked as not ready.",
+						},
+						"port": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     8080,
+							Description: "The port to use for the readiness probe.",
+						},
+						"period_seconds": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     10,
+							Description: "The period (in seconds) between readiness probe checks.",
+						},
+						"timeout_seconds": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     10,
+							Description: "The timeout (in seconds) for the readiness probe.",
+						},
+						"path": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "/health",
+							Description: "The path to check for the readiness probe.",
+						},
+						"grpc_service": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "",
+							Description: "The gRPC service to check for the readiness probe.",
+						},
+						"commands": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "",
+							Description: "Commands to execute for the readiness probe.",
+						},
+					},
+				},
+			},
+			"liveness_probe": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Default:     nil,
+				Description: "Configuration for the liveness probe.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"protocol": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "http",
+							Description: "The protocol to use for the liveness probe (e.g., http, tcp).",
+						},
+						"initial_delay_seconds": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     10,
+							Description: "The initial delay (in seconds) before the liveness probe starts.",
+						},
+						"success_threshold": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     1,
+							Description: "The number of successful probes required to mark the resource as live.",
+						},
+						"failure_threshold": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     3,
+							Description: "The number of failed probes before the resource is marked as not live.",
+						},
+						"port": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     8080,
+							Description: "The port to use for the liveness probe.",
+						},
+						"period_seconds": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     10,
+							Description: "The period (in seconds) between liveness probe checks.",
+						},
+						"timeout_seconds": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     10,
+							Description: "The timeout (in seconds) for the liveness probe.",
+						},
+						"path": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "/metrics",
+							Description: "The path to check for the liveness probe.",
+						},
+						"grpc_service": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "",
+							Description: "The gRPC service to check for the liveness probe.",
+						},
+						"commands": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "",
+							Description: "Commands to execute for the liveness probe.",
+						},
+					},
+				},
+			},
+			"resource_details": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Default:     nil,
+				Description: "Additional details about the resource, such as disk size, mount path, and environment variables.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"disk_size": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     100,
+							Description: "The size of the disk (in GB) allocated for the resource.",
+						},
+						"mount_path": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "",
+							Description: "The path where the disk is mounted.",
+						},
+						"env_variables": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Default:     nil,
+							Description: "Environment variables to be set for the resource.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"key": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "The key for the environment variable.",
+									},
+									"value": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "The value for the environment variable.",
+									},
+									"required": {
+										Type:        schema.TypeBool,
+										Optional:    true,
+										Description: "Indicates whether the environment variable is required.",
+									},
+									"disabled": {
+										Type:        schema.TypeMap,
+										Optional:    true,
+										Description: "A map of disabled environment variables.",
+										Elem: &schema.Schema{
+											Type: schema.TypeBool,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"public_ip": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "no",
+				Description: "Indicates whether a public IP address is assigned to the resource.",
+			},
+			"container_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the container associated with the resource. This is computed automatically.",
+			},
+			"container_type": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The type of container used for the resource (e.g., public, private).",
+			},
+			"private_cloud_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "The ID of the private cloud where the resource is deployed.",
+			},
+			"custom_sku": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Default:     nil,
+				Description: "A map of custom SKU configurations for the private cloud .",
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+			"service_port": {
+				Type:        schema.TypeBool,
+				Default:     false,
+				Optional:    true,
+				Description: "Indicates whether a service port is exposed for the resource.",
+			},
+			"metric_port": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Indicates whether a metric port is exposed for the resource.",
+			},
+			"dataset_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "The ID of the dataset associated with the resource.",
+			},
+			"dataset_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "The path to the dataset used by the resource.",
+			},
+			"team_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the team that owns the resource.",
+			},
+			"active_iam": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The IAM (Identity and Access Management) role associated with the resource.",
+			},
+			"project_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the project where the resource is deployed.",
+			},
+			"location": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The location or region where the resource is deployed.",
+			},
+			"currency": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The currency used for billing the resource.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The current status of the resource. This is computed automatically.",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The timestamp when the resource was created. This is computed automatically.",
+			},
+			"stop_inference": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "start",
+				Description: "Indicates whether to stop or start inference for the resource. Default is 'start'.",
+			},
+		},
+	}
+}
@@ -0,0 +1,80 @@
+package modelEndpoint
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestUpgradeModelEndpointStateV0(t *testing.T) {
+	cases := []struct {
+		name  string
+		state map[string]interface{}
+		want  map[string]interface{}
+	}{
+		{
+			name:  "start is kept",
+			state: map[string]interface{}{"stop_inference": "start", "status": "stopped"},
+			want:  map[string]interface{}{"stop_inference": "start", "status": "stopped"},
+		},
+		{
+			name:  "stop is kept",
+			state: map[string]interface{}{"stop_inference": "stop", "status": "running"},
+			want:  map[string]interface{}{"stop_inference": "stop", "status": "running"},
+		},
+		{
+			name:  "case and spaces are normalised",
+			state: map[string]interface{}{"stop_inference": " STOP ", "status": "running"},
+			want:  map[string]interface{}{"stop_inference": "stop", "status": "running"},
+		},
+		{
+			name:  "invalid value of a stopped endpoint",
+			state: map[string]interface{}{"stop_inference": "yes", "status": "stopped"},
+			want:  map[string]interface{}{"stop_inference": "stop", "status": "stopped"},
+		},
+		{
+			name:  "invalid value of a running endpoint",
+			state: map[string]interface{}{"stop_inference": "yes", "status": "running"},
+			want:  map[string]interface{}{"stop_inference": "start", "status": "running"},
+		},
+		{
+			name:  "unset value without status",
+			state: map[string]interface{}{"name": "endpoint"},
+			want:  map[string]interface{}{"name": "endpoint", "stop_inference": "start"},
+		},
+		{
+			name:  "no state",
+			state: nil,
+			want:  nil,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := upgradeModelEndpointStateV0(context.Background(), tc.state, nil)
+			if err != nil {
+				t.Fatalf("upgradeModelEndpointStateV0() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("upgradeModelEndpointStateV0() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestModelEndpointStateUpgraders(t *testing.T) {
+	upgraders := modelEndpointStateUpgraders()
+	if got, want := len(upgraders), ResourceModel().SchemaVersion; got != want {
+		t.Fatalf("%d state upgraders, want one per schema version before %d", got, want)
+	}
+	for i, upgrader := range upgraders {
+		if upgrader.Version != i {
+			t.Errorf("upgrader %d is for version %d", i, upgrader.Version)
+		}
+		if !upgrader.Type.IsObjectType() {
+			t.Errorf("upgrader %d decodes state as %s, want an object", i, upgrader.Type.FriendlyName())
+		}
+	}
+	if !upgraders[0].Type.HasAttribute("stop_inference") {
+		t.Error("the version 0 schema has no stop_inference attribute")
+	}
+}
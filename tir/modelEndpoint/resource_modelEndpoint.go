@@ -11,7 +11,13 @@ import (
 	"github.com/e2eterraformprovider/terraform-provider-tir/constants"
 	"github.com/e2eterraformprovider/terraform-provider-tir/models"
 	"github.com/e2eterraformprovider/terraform-provider-tir/client"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/naming"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/notfound"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/identity"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/labels"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -20,10 +26,13 @@ func ResourceModel() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The name of the resource. This is a required field and must be unique within the project.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"name", "name_prefix"},
+				Description:  "The name of the resource. It must be unique within the project. Either name or name_prefix must be set.",
 			},
+			"name_prefix": naming.PrefixSchema("name", "endpoint"),
 			"server_options": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -481,7 +490,15 @@ func ResourceModel() *schema.Resource {
 									"value": {
 										Type:        schema.TypeString,
 										Optional:    true,
-										Description: "The value for the environment variable.",
+										Sensitive:   true,
+										Description: "The value for the environment variable. Every value is sensitive, it is kept out of the plan output and redacted from the provider logs.",
+									},
+									"value_wo": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Sensitive:   true,
+										WriteOnly:   true,
+										Description: "Write-only value for the environment variable. It is sent to TIR but never stored in the plan or state. Requires Terraform 1.11 or later.",
 									},
 									"required": {
 										Type:        schema.TypeBool,
@@ -502,6 +519,18 @@ func ResourceModel() *schema.Resource {
 					},
 				},
 			},
+			"env_variables_wo_keys": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The keys of the environment variables set through value_wo. Their values are never read back from TIR into the state.",
+			},
+			"env_variables_wo_version": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "Version of the write-only environment variable values. Change it to push new value_wo secrets to the endpoint, as write-only values do not produce a diff on their own.",
+			},
 			"public_ip": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -587,6 +616,14 @@ func ResourceModel() *schema.Resource {
 				Computed:    true,
 				Description: "The current status of the resource. This is computed automatically.",
 			},
+			"labels":     labels.Schema("model endpoint"),
+			"labels_all": labels.AllSchema("model endpoint"),
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Prevents Terraform from deleting the endpoint while true. Set it to false in a separate apply before destroying or replacing the endpoint. Default is false.",
+			},
 			"created_at": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -596,18 +633,47 @@ func ResourceModel() *schema.Resource {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Default:     "start",
-				Description: "Indicates whether to stop or start inference for the resource. Default is 'start'.",
+				Description: "Stops inference when set to 'stop' and starts it again when set back to 'start'. It is not refreshed from the endpoint status, so an endpoint stopped or started by the tir_model_endpoint_stop and tir_model_endpoint_start actions is left as it is. Default is 'start'.",
+				ValidateFunc: validation.StringInSlice([]string{
+					"start",
+					"stop",
+				}, false),
+			},
+			"estimated_hourly_cost": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "The estimated hourly cost of the endpoint across all replicas in the selected currency, derived from the SKU catalog.",
+			},
+			"estimated_monthly_cost": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "The estimated monthly (730 hours) cost of the endpoint across all replicas in the selected currency.",
+			},
+			"committed_total_cost": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "The total cost of the committed period for committed SKUs. Zero for hourly SKUs.",
 			},
 		},
-		CreateContext: resourceCreateModelEndpoint,
-		ReadContext:   resourceReadModelEndpoint,
-		UpdateContext: resourceUpdateModelEndpoint,
-		DeleteContext: resourceDeleteModelEndpoint,
+		CreateContext:  resourceCreateModelEndpoint,
+		ReadContext:    resourceReadModelEndpoint,
+		UpdateContext:  resourceUpdateModelEndpoint,
+		DeleteContext:  resourceDeleteModelEndpoint,
+		CustomizeDiff:  customdiff.All(customizeEndpointDiff, customizeEndpointWriteOnlyEnvDiff, labels.CustomizeDiff),
+		SchemaVersion:  1,
+		StateUpgraders: modelEndpointStateUpgraders(),
+		Identity:       identity.Schema(),
+		Importer: &schema.ResourceImporter{
+			StateContext: identity.ImportState,
+		},
 	}
 }
 
 func resourceCreateModelEndpoint(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	apiClient := m.(*client.Client)
+	if _, err := naming.Resolve(d, "name"); err != nil {
+		return diag.FromErr(err)
+	}
 	log.Println("Starting resourceCreateModelEndpoint function")
 	if d.Get("stop_inference") != "start" {
 		return diag.Errorf("Field stop_inference must be [start] at the time of creation")
@@ -627,7 +693,11 @@ func resourceCreateModelEndpoint(ctx context.Context, d *schema.ResourceData, m
 	originalCommands := detailedInfo["commands"].(string)
 	originalArgs := detailedInfo["args"].(string)
 
-	_, endpointNode := createPayloadForInference(d)
+	payloadDiags, endpointNode := createPayloadForInference(d)
+	if payloadDiags.HasError() {
+		return payloadDiags
+	}
+	endpointNode.Labels = labels.Expand(d, m)
 	// log.Println("Repository JSON:", buf)
 
 	response, error := apiClient.NewEndoint(&endpointNode, d.Get("project_id").(string), d.Get("team_id").(string), d.Get("active_iam").(string))
@@ -649,6 +719,9 @@ func resourceCreateModelEndpoint(ctx context.Context, d *schema.ResourceData, m
 	detailedInfo["args"] = originalArgs
 	d.Set("status", data["status"].(string))
 	d.Set("created_at", data["created_at"].(string))
+	if err := identity.Set(d); err != nil {
+		return diag.FromErr(err)
+	}
 	log.Println("resourceCreateModelEndpoint completed successfully")
 	var diags diag.Diagnostics
 	return diags
@@ -660,16 +733,22 @@ func resourceReadModelEndpoint(ctx context.Context, d *schema.ResourceData, m in
 
 	response, err := apiClient.GetEndpoint(endpointID, d.Get("project_id").(string), d.Get("team_id").(string), d.Get("active_iam").(string))
 	if err != nil {
-		if strings.Contains(err.Error(), "404 Not Found") {
-			log.Println("[INFO] Repo not found, setting ID to empty")
-			d.SetId("")
-			return nil
+		if client.IsNotFound(err) {
+			return notfound.Remove(d, "model endpoint")
 		}
 		return diag.Errorf("Error finding item with id: %s - %v", endpointID, err)
 	}
 	if err := client.SetSchemaFromResponse(d, response); err != nil {
 		return diag.FromErr(err)
 	}
+	if data, ok := response["data"].(map[string]interface{}); ok {
+		if err := labels.Set(d, m, data["labels"]); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err := identity.Set(d); err != nil {
+		return diag.FromErr(err)
+	}
 	return nil
 }
 
@@ -682,16 +761,23 @@ func resourceUpdateModelEndpoint(ctx context.Context, d *schema.ResourceData, m
 	activeIAM := d.Get("active_iam").(string)
 	action := d.Get("stop_inference").(string)
 
+	if !d.HasChangeExcept("deletion_protection") {
+		return diags
+	}
 	if d.HasChange("framework") {
 		oldFramework, _ := d.GetChange("framework")
 		d.Set("framework", oldFramework)
 		return diag.Errorf("You cannot change framework once created inference!!")
 	}
 	if d.HasChange("stop_inference") {
-		_, err := apiClient.UpdateStartStopInference(endpointID, projectID, teamID, activeIAM, action)
-		if err != nil {
-			d.Set("stop_inference", "start")
-			return diag.Errorf("Not able to stop/start node")
+		// stop_inference is not refreshed from the status, so the endpoint may already be where
+		// the new value wants it, for example after a tir_model_endpoint_stop action.
+		if (action == "stop") != (d.Get("status") == "stopped") {
+			_, err := apiClient.UpdateStartStopInference(endpointID, projectID, teamID, activeIAM, action)
+			if err != nil {
+				d.Set("stop_inference", "start")
+				return diag.Errorf("Not able to stop/start node")
+			}
 		}
 	} else {
 		detailedInfoList := d.Get("detailed_info").([]interface{})
@@ -704,6 +790,7 @@ func resourceUpdateModelEndpoint(ctx context.Context, d *schema.ResourceData, m
 			log.Println(err)
 			return diag.Errorf("Something went wrong creating payload,,,please check the config")
 		}
+		endpointNode.Labels = labels.Expand(d, m)
 		if d.Get("status") == "stopped" {
 			endpointNode.Action = "update"
 		} else {
@@ -716,12 +803,15 @@ func resourceUpdateModelEndpoint(ctx context.Context, d *schema.ResourceData, m
 		detailedInfo["engine_args"] = originalEngineArgs
 		detailedInfo["commands"] = originalCommands
 		detailedInfo["args"] = originalArgs
-		log.Println("updated response", response)
+		log.Println("updated response", client.RedactJSON(response))
 	}
 	return diags
 }
 
 func resourceDeleteModelEndpoint(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if d.Get("deletion_protection").(bool) {
+		return diag.Errorf("Cannot delete model endpoint %s while deletion_protection is true. Set deletion_protection to false and apply before destroying it.", d.Id())
+	}
 	var diags diag.Diagnostics
 	apiClient := m.(*client.Client)
 	endpointID := d.Id()
@@ -739,18 +829,27 @@ func resourceDeleteModelEndpoint(ctx context.Context, d *schema.ResourceData, m
 	return diags
 }
 
-func buildEnvVariablesFromSchema(resource_details map[string]interface{}) ([]models.EnvVariables, error) {
+func buildEnvVariablesFromSchema(d *schema.ResourceData, resource_details map[string]interface{}) ([]models.EnvVariables, error) {
 
 	var envVars []models.EnvVariables
 	if rawEnvVars, ok := resource_details["env_variables"]; ok {
 		envVarsList := rawEnvVars.([]interface{})
-		for _, rawEnvVar := range envVarsList {
+		for i, rawEnvVar := range envVarsList {
 			envVarMap := rawEnvVar.(map[string]interface{})
 			envVar := models.EnvVariables{
 				Key:      envVarMap["key"].(string),
 				Value:    envVarMap["value"].(string),
 				Required: envVarMap["required"].(bool),
 			}
+			// write-only values only exist in the raw config, never in d.Get
+			valuePath := cty.GetAttrPath("resource_details").IndexInt(0).GetAttr("env_variables").IndexInt(i).GetAttr("value_wo")
+			valueWO, diags := d.GetRawConfigAt(valuePath)
+			if diags.HasError() {
+				return nil, fmt.Errorf("not able to read value_wo of env variable %s", envVar.Key)
+			}
+			if !valueWO.IsNull() && valueWO.IsKnown() {
+				envVar.Value = valueWO.AsString()
+			}
 			if disabled, ok := envVarMap["disabled"]; ok {
 				disabledMap := disabled.(map[string]interface{})
 				envVar.Disabled = disabledMap
@@ -762,6 +861,42 @@ func buildEnvVariablesFromSchema(resource_details map[string]interface{}) ([]mod
 	return envVars, nil
 }
 
+// customizeEndpointWriteOnlyEnvDiff records in env_variables_wo_keys which env variables are set
+// through value_wo. Write-only values are only in the config, so this is the one place the
+// provider learns about them before the endpoint is read again.
+func customizeEndpointWriteOnlyEnvDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() || !config.Type().IsObjectType() {
+		return nil
+	}
+	keys := []interface{}{}
+	details := config.GetAttr("resource_details")
+	if details.IsNull() || !details.IsKnown() {
+		return d.SetNewComputed("env_variables_wo_keys")
+	}
+	for it := details.ElementIterator(); it.Next(); {
+		_, detail := it.Element()
+		envVariables := detail.GetAttr("env_variables")
+		if envVariables.IsNull() {
+			continue
+		}
+		if !envVariables.IsKnown() {
+			return d.SetNewComputed("env_variables_wo_keys")
+		}
+		for envIt := envVariables.ElementIterator(); envIt.Next(); {
+			_, envVariable := envIt.Element()
+			key := envVariable.GetAttr("key")
+			if !key.IsKnown() {
+				return d.SetNewComputed("env_variables_wo_keys")
+			}
+			if !key.IsNull() && !envVariable.GetAttr("value_wo").IsNull() {
+				keys = append(keys, key.AsString())
+			}
+		}
+	}
+	return d.SetNew("env_variables_wo_keys", keys)
+}
+
 func convertEngineArgs(engineArgsMap map[string]interface{}) (map[string]interface{}, error) {
 	convertedMap := make(map[string]interface{})
 
@@ -833,18 +968,22 @@ func createPayloadForInference(d *schema.ResourceData) (diag.Diagnostics, models
 		ReadinessProbe:          readinessProbeNode,
 		LivenessProbe:           livenessProbeNode,
 	}
-	log.Println("Advanced config node created:", advancedConfigNode)
+	log.Printf("[DEBUG] Advanced config node created: %s", client.RedactJSON(advancedConfigNode))
 
 	rd := d.Get("resource_details").([]interface{})
 	resource_details := rd[0].(map[string]interface{})
-	log.Println("resource_details", resource_details)
-	envVarNode, _ := buildEnvVariablesFromSchema(resource_details)
+	log.Println("resource_details", client.RedactJSON(resource_details))
+	envVarNode, envErr := buildEnvVariablesFromSchema(d, resource_details)
+	if envErr != nil {
+		log.Println("Error building env variables:", envErr)
+		return diag.FromErr(envErr), models.ModelEndpoint{}
+	}
 	resourceDetailsNode := models.ResourceDetails{
 		DiskSize:     resource_details["disk_size"].(int),
 		MountPath:    resource_details["mount_path"].(string),
 		EnvVariables: envVarNode,
 	}
-	log.Println("Resource details node created:", resourceDetailsNode)
+	log.Println("Resource details node created:", client.RedactJSON(resourceDetailsNode))
 	di := d.Get("detailed_info").([]interface{})
 	detailed_info := di[0].(map[string]interface{})
 	err, containerName := constants.GetContainerName(detailed_info["server_version"].(string), d.Get("model_id").(string), d.Get("framework").(string))
@@ -861,7 +1000,7 @@ func createPayloadForInference(d *schema.ResourceData) (diag.Diagnostics, models
 		PrivateImageDetails: models.PrivateImageDetails{}, // if want to make private image then there is a field registry_namespace_id
 		AdvanceConfig:       advancedConfigNode,
 	}
-	log.Println("Container node created:", containerNode)
+	log.Printf("[DEBUG] Container node created: %s", client.RedactJSON(containerNode))
 
 	customEndpointDetailsNode := models.CustomEndpointDetails{
 		ServicePort:     d.Get("service_port").(bool),
@@ -870,23 +1009,20 @@ func createPayloadForInference(d *schema.ResourceData) (diag.Diagnostics, models
 		ResourceDetails: resourceDetailsNode,
 		PublicIP:        "no",
 	}
-	log.Println("Custom endpoint details node created:", customEndpointDetailsNode)
+	log.Println("Custom endpoint details node created:", client.RedactJSON(customEndpointDetailsNode))
 	frameName, _ := constants.GetFrameworkName(d.Get("framework").(string))
 
 	// originalEngineArgs := detailed_info["engine_args"].(map[string]interface{})
 	engine_args, _ := convertEngineArgs(detailed_info["engine_args"].(map[string]interface{}))
 	detailed_info["engine_args"] = engine_args
-	log.Println("before")
 	commands := detailed_info["commands"].(string)
 	args := detailed_info["args"].(string)
-	log.Println("after")
 	detailed_info["commands"] = base64.StdEncoding.EncodeToString([]byte(commands))
 	detailed_info["args"] = base64.StdEncoding.EncodeToString([]byte(args))
 	if d.Get("framework").(string) != "VLLM" && d.Get("framework").(string) != "DYNAMO" && d.Get("framework").(string) != "SGLANG" {
 		detailed_info["hugging_face_id"] = constants.GetDefaultHuggingFaceID(d.Get("framework").(string))
 	}
-	log.Println("engine_args", engine_args)
-	log.Println("detailed_info", detailed_info)
+	log.Printf("[DEBUG] detailed_info %s", client.RedactJSON(detailed_info))
 	endpointNode := models.ModelEndpoint{
 		Name:                   d.Get("name").(string),
 		Path:                   d.Get("model_path").(string),
@@ -908,20 +1044,17 @@ func createPayloadForInference(d *schema.ResourceData) (diag.Diagnostics, models
 		Location:               d.Get("location").(string),
 		Currency:               d.Get("currency").(string),
 	}
-	log.Println("Endpoint node created:", endpointNode)
+	log.Println("Endpoint node created:", client.RedactJSON(endpointNode))
 
 	if d.Get("model_id") != "" {
-		log.Println("line 748", d.Get("model_id").(string))
 		ModelID, _ := strconv.Atoi(d.Get("model_id").(string))
 		endpointNode.ModelID = &ModelID
 	} else if d.Get("model_load_integration_id") != "" {
-		log.Println("line 752", d.Get("model_load_integration_id").(string))
 		ModelIntegrationID, _ := strconv.Atoi(d.Get("model_load_integration_id").(string))
 		endpointNode.ModelLoadIntegrationID = &ModelIntegrationID
 	}
 
 	if d.Get("private_cloud_id").(string) != "" {
-		log.Println("line 758", d.Get("private_cloud_id").(string))
 		private_cloud_id, _ := strconv.Atoi(d.Get("private_cloud_id").(string))
 		endpointNode.PrivateCloudID = &private_cloud_id
 		if d.Get("custom_sku") == nil {
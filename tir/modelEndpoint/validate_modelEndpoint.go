@@ -0,0 +1,49 @@
+package modelEndpoint
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/e2eterraformprovider/terraform-provider-tir/client"
+	"github.com/e2eterraformprovider/terraform-provider-tir/constants"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/catalog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// customizeEndpointDiff checks the framework and the selected SKU plan against the inference
+// SKU catalog at plan time and fills in the estimated cost for the configured replicas.
+// Endpoints on a private cluster use custom_sku and are not priced per SKU.
+func customizeEndpointDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	keys := []string{"framework", "sku_name", "sku_type", "currency", "committed_days", "replica", "committed_replicas", "private_cloud_id", "active_iam"}
+	if d.Id() != "" && !d.HasChanges(keys...) {
+		return nil
+	}
+	for _, key := range keys {
+		if !d.NewValueKnown(key) {
+			log.Printf("[DEBUG] %s is unknown at plan time, skipping catalog validation", key)
+			return catalog.SetEstimatedCostUnknown(d)
+		}
+	}
+	framework := d.Get("framework").(string)
+	if _, diags := constants.GetFrameworkName(framework); diags != nil {
+		return catalog.DidYouMean("framework", framework, constants.GetFrameworkNames())
+	}
+	if d.Get("private_cloud_id").(string) != "" {
+		return catalog.SetEstimatedCost(d, catalog.Cost{})
+	}
+	apiClient := m.(*client.Client)
+	plans, err := apiClient.GetPlansModelEndpoint(d.Get("active_iam").(string), framework)
+	if err != nil {
+		return fmt.Errorf("not able to fetch the plan catalog: %s", err)
+	}
+	plan, err := catalog.FindSKUPlan(catalog.ParseSKUPlans(plans), d.Get("sku_name").(string), d.Get("sku_type").(string), d.Get("currency").(string), d.Get("committed_days").(int))
+	if err != nil {
+		return fmt.Errorf("%s for framework %s", err, framework)
+	}
+	replicas := d.Get("replica").(int)
+	if plan.SKUType == "committed" && d.Get("committed_replicas").(int) > 0 {
+		replicas = d.Get("committed_replicas").(int)
+	}
+	return catalog.SetEstimatedCost(d, catalog.EstimateCost(plan, replicas))
+}
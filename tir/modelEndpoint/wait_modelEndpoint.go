@@ -0,0 +1,48 @@
+package modelEndpoint
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/e2eterraformprovider/terraform-provider-tir/client"
+)
+
+// endpointPollInterval is how often the endpoint status is polled while waiting for a transition.
+var endpointPollInterval = 10 * time.Second
+
+// waitForEndpointStatus polls the endpoint until its status matches target. It gives up when the
+// endpoint reports a failure or the timeout passes. In dry run mode nothing changed in TIR, so
+// it returns right away.
+func waitForEndpointStatus(ctx context.Context, apiClient *client.Client, endpointID string, projectID string, teamID string, activeIAM string, target string, timeout time.Duration) error {
+	if apiClient.DryRun() {
+		log.Printf("[INFO] dry run: not waiting for endpoint %s to be %q", endpointID, target)
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	for {
+		response, err := apiClient.GetEndpoint(endpointID, projectID, teamID, activeIAM)
+		if err != nil {
+			return err
+		}
+		status := ""
+		if data, ok := response["data"].(map[string]interface{}); ok {
+			status, _ = data["status"].(string)
+		}
+		log.Printf("[INFO] Endpoint %s is %q, waiting for %q", endpointID, status, target)
+		if strings.EqualFold(status, target) {
+			return nil
+		}
+		if strings.EqualFold(status, "failed") || strings.EqualFold(status, "error") {
+			return fmt.Errorf("endpoint %s is %q while waiting for it to be %q", endpointID, status, target)
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for endpoint %s to be %q, it is %q", endpointID, target, status)
+		case <-time.After(endpointPollInterval):
+		}
+	}
+}
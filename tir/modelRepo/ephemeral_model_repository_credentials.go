@@ -0,0 +1,19 @@
+package modelRepo
+
+import (
+	"github.com/e2eterraformprovider/terraform-provider-tir/client"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/bucketcredentials"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+)
+
+// NewModelRepositoryCredentialsEphemeralResource returns tir_model_repository_credentials, the
+// bucket credentials of a tir_model_repository.
+func NewModelRepositoryCredentialsEphemeralResource() ephemeral.EphemeralResource {
+	return bucketcredentials.NewEphemeralResource(bucketcredentials.Asset{
+		TypeName:     "_model_repository_credentials",
+		IDAttribute:  "repository_id",
+		Kind:         "model repository",
+		ResourceType: "tir_model_repository",
+		Get:          (*client.Client).GetRepo,
+	})
+}
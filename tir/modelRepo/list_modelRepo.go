@@ -0,0 +1,12 @@
+package modelRepo
+
+import (
+	"github.com/e2eterraformprovider/terraform-provider-tir/client"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/listing"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+)
+
+// NewModelRepoListResource returns the tir_model_repository list resource used by `terraform query`.
+func NewModelRepoListResource() list.ListResource {
+	return listing.New("tir_model_repository", "model repository", "name", ResourceModelRepo, (*client.Client).ListRepos)
+}
@@ -8,6 +8,10 @@ import (
 	"strings"
 
 	"github.com/e2eterraformprovider/terraform-provider-tir/client"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/naming"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/notfound"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/identity"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/labels"
 	"github.com/e2eterraformprovider/terraform-provider-tir/models"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -18,10 +22,13 @@ func ResourceModelRepo() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The name of the model repository. This is a required field and must be unique.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"name", "name_prefix"},
+				Description:  "The name of the model repository. It must be unique. Either name or name_prefix must be set.",
 			},
+			"name_prefix": naming.PrefixSchema("name", "model repository"),
 			"storage_type": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -53,6 +60,7 @@ func ResourceModelRepo() *schema.Resource {
 			},
 			"access_key": {
 				Type:        schema.TypeString,
+				Sensitive:   true,
 				Optional:    true,
 				Default:     "",
 				Description: "The access key for the model repository. This is optional and will be auto-generated if not provided.",
@@ -62,6 +70,7 @@ func ResourceModelRepo() *schema.Resource {
 			},
 			"secret_key": {
 				Type:        schema.TypeString,
+				Sensitive:   true,
 				Optional:    true,
 				Default:     "",
 				Description: "The secret key for the model repository. This is optional and will be auto-generated if not provided.",
@@ -74,6 +83,14 @@ func ResourceModelRepo() *schema.Resource {
 				Computed:    true,
 				Description: "The current status of the model repository. This is computed automatically.",
 			},
+			"labels":     labels.Schema("model repository"),
+			"labels_all": labels.AllSchema("model repository"),
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Prevents Terraform from deleting the model repository and its bucket data while true. Set it to false in a separate apply before destroying or replacing the model repository. Default is false.",
+			},
 			"created_at": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -99,11 +116,19 @@ func ResourceModelRepo() *schema.Resource {
 		UpdateContext: resourceUpdateModelRepo,
 		ReadContext:   resourceReadModelRepo,
 		DeleteContext: resourceDeleteModelRepo,
+		CustomizeDiff: labels.CustomizeDiff,
+		Identity:      identity.Schema(),
+		Importer: &schema.ResourceImporter{
+			StateContext: identity.ImportState,
+		},
 	}
 }
 
 func resourceCreateModelRepo(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	apiClient := m.(*client.Client)
+	if _, err := naming.Resolve(d, "name"); err != nil {
+		return diag.FromErr(err)
+	}
 
 	storage_type := ""
 	if d.Get("storage_type") == "new" {
@@ -121,6 +146,7 @@ func resourceCreateModelRepo(ctx context.Context, d *schema.ResourceData, m inte
 		StorageType: storage_type,
 		SecretKey:   d.Get("secret_key").(string),
 		AccessKey:   d.Get("access_key").(string),
+		Labels:      labels.Expand(d, m),
 	}
 
 	response, err := apiClient.NewRepo(&repo, d.Get("project_id").(string), d.Get("team_id").(string), d.Get("active_iam").(string))
@@ -130,6 +156,9 @@ func resourceCreateModelRepo(ctx context.Context, d *schema.ResourceData, m inte
 	}
 	data := response["data"].(map[string]interface{})
 	d.SetId(strconv.Itoa(int(math.Round(data["id"].(float64)))))
+	if err := identity.Set(d); err != nil {
+		return diag.FromErr(err)
+	}
 	return nil
 }
 
@@ -139,10 +168,8 @@ func resourceReadModelRepo(ctx context.Context, d *schema.ResourceData, m interf
 
 	response, err := apiClient.GetRepo(repoID, d.Get("project_id").(string), d.Get("team_id").(string), d.Get("active_iam").(string))
 	if err != nil {
-		if strings.Contains(err.Error(), "404 Not Found") {
-			log.Println("[INFO] Repo not found, setting ID to empty")
-			d.SetId("")
-			return nil
+		if client.IsNotFound(err) {
+			return notfound.Remove(d, "model repository")
 		}
 		return diag.Errorf("Error finding item with id: %s - %v", repoID, err)
 	}
@@ -158,15 +185,27 @@ func resourceReadModelRepo(ctx context.Context, d *schema.ResourceData, m interf
 	d.Set("created_at", data["created_at"])
 	d.Set("model_type", data["model_type"])
 	d.Set("name", data["name"])
+	if err := labels.Set(d, m, data["labels"]); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := identity.Set(d); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return nil
 }
 
 func resourceUpdateModelRepo(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := labels.Update(d, m, client.ModelRepoCollection); err != nil {
+		return diag.Errorf("Updating labels failed: %s", err)
+	}
 	return nil
 }
 
 func resourceDeleteModelRepo(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if d.Get("deletion_protection").(bool) {
+		return diag.Errorf("Cannot delete model repository %s while deletion_protection is true. Set deletion_protection to false and apply before destroying it.", d.Id())
+	}
 	var diags diag.Diagnostics
 	apiClient := m.(*client.Client)
 	repoID := d.Id()
@@ -0,0 +1,68 @@
+package naming
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// MaxLength is the longest name TIR accepts for nodes, endpoints and the other named resources,
+// the limit the TIR console enforces on its name fields. tir_node_image names are held to it too.
+const MaxLength = 50
+
+// SuffixLength is the number of random characters appended to a name_prefix.
+const SuffixLength = 8
+
+const suffixAlphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// prefixPattern follows the TIR naming rules: names start with a letter and only contain
+// letters, digits and hyphens.
+var prefixPattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9-]*$`)
+
+// PrefixSchema returns the name_prefix attribute for a resource whose name is stored in
+// nameAttribute. The prefix leaves room for the hyphen and the generated suffix.
+func PrefixSchema(nameAttribute string, kind string) *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ForceNew:     true,
+		ExactlyOneOf: []string{nameAttribute, "name_prefix"},
+		ValidateFunc: validation.All(
+			validation.StringLenBetween(1, MaxLength-SuffixLength-1),
+			validation.StringMatch(prefixPattern, "must start with a letter and contain only letters, digits and hyphens"),
+		),
+		Description: fmt.Sprintf("Creates a unique %s name beginning with this prefix, followed by a hyphen and %d random lowercase letters and digits. At most %d characters, so the name fits the %d characters TIR allows. Conflicts with %s.", kind, SuffixLength, MaxLength-SuffixLength-1, MaxLength, nameAttribute),
+	}
+}
+
+// Generate returns prefix followed by a hyphen and a random suffix. It fails only when the
+// system random source does.
+func Generate(prefix string) (string, error) {
+	suffix := make([]byte, SuffixLength)
+	for i := range suffix {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(suffixAlphabet))))
+		if err != nil {
+			return "", fmt.Errorf("generating a name from name_prefix %q: %w", prefix, err)
+		}
+		suffix[i] = suffixAlphabet[n.Int64()]
+	}
+	return prefix + "-" + string(suffix), nil
+}
+
+// Resolve returns the name to create the resource with: the configured name, or a name generated
+// from name_prefix. The result is stored in nameAttribute so it ends up in state.
+func Resolve(d *schema.ResourceData, nameAttribute string) (string, error) {
+	if name, ok := d.GetOk(nameAttribute); ok {
+		return name.(string), nil
+	}
+	name, err := Generate(d.Get("name_prefix").(string))
+	if err != nil {
+		return "", err
+	}
+	d.Set(nameAttribute, name)
+	return name, nil
+}
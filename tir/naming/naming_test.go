@@ -0,0 +1,100 @@
+package naming
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestGenerate(t *testing.T) {
+	suffix := regexp.MustCompile(`^[a-z0-9]{8}$`)
+	for _, prefix := range []string{"node", "my-endpoint", "A1"} {
+		t.Run(prefix, func(t *testing.T) {
+			name, err := Generate(prefix)
+			if err != nil {
+				t.Fatalf("Generate(%q) unexpected error: %v", prefix, err)
+			}
+			if !strings.HasPrefix(name, prefix+"-") {
+				t.Fatalf("Generate(%q) = %q, want the prefix followed by a hyphen", prefix, name)
+			}
+			if got := strings.TrimPrefix(name, prefix+"-"); !suffix.MatchString(got) {
+				t.Errorf("Generate(%q) suffix = %q, want %d lowercase letters and digits", prefix, got, SuffixLength)
+			}
+		})
+	}
+	first, _ := Generate("node")
+	second, _ := Generate("node")
+	if first == second {
+		t.Error("Generate() returned the same name twice")
+	}
+}
+
+func TestPrefixSchema(t *testing.T) {
+	cases := []struct {
+		prefix  string
+		wantErr bool
+	}{
+		{prefix: "node", wantErr: false},
+		{prefix: "My-Node-2", wantErr: false},
+		{prefix: strings.Repeat("a", MaxLength-SuffixLength-1), wantErr: false},
+		{prefix: strings.Repeat("a", MaxLength-SuffixLength), wantErr: true},
+		{prefix: "1node", wantErr: true},
+		{prefix: "-node", wantErr: true},
+		{prefix: "node_1", wantErr: true},
+		{prefix: "", wantErr: true},
+	}
+	s := PrefixSchema("node_name", "node")
+	for _, tc := range cases {
+		t.Run(tc.prefix, func(t *testing.T) {
+			_, errs := s.ValidateFunc(tc.prefix, "name_prefix")
+			if (len(errs) > 0) != tc.wantErr {
+				t.Errorf("validating %q: errors = %v, want error %t", tc.prefix, errs, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	resourceSchema := map[string]*schema.Schema{
+		"name": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ExactlyOneOf: []string{"name", "name_prefix"},
+		},
+		"name_prefix": PrefixSchema("name", "test"),
+	}
+	cases := []struct {
+		name   string
+		config map[string]interface{}
+		want   *regexp.Regexp
+	}{
+		{
+			name:   "configured name",
+			config: map[string]interface{}{"name": "exact"},
+			want:   regexp.MustCompile(`^exact$`),
+		},
+		{
+			name:   "name prefix",
+			config: map[string]interface{}{"name_prefix": "web"},
+			want:   regexp.MustCompile(`^web-[a-z0-9]{8}$`),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceSchema, tc.config)
+			got, err := Resolve(d, "name")
+			if err != nil {
+				t.Fatalf("Resolve() unexpected error: %v", err)
+			}
+			if !tc.want.MatchString(got) {
+				t.Errorf("Resolve() = %q, want a match of %s", got, tc.want)
+			}
+			if stored := d.Get("name").(string); stored != got {
+				t.Errorf("name = %q, want %q", stored, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package notebook
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/e2eterraformprovider/terraform-provider-tir/client"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ action.ActionWithConfigure = &nodeAction{}
+
+// defaultNodeActionTimeout bounds how long an action waits for the node to settle.
+const defaultNodeActionTimeout = 20 * time.Minute
+
+// nodeAction starts, stops or restarts a notebook node and waits until the node has reached the
+// resulting status. It replaces toggling stop_node for one-off operations.
+type nodeAction struct {
+	operation string
+	client    *client.Client
+}
+
+// NewNodeStopAction returns the tir_node_stop action.
+func NewNodeStopAction() action.Action {
+	return &nodeAction{operation: "stop"}
+}
+
+// NewNodeStartAction returns the tir_node_start action.
+func NewNodeStartAction() action.Action {
+	return &nodeAction{operation: "start"}
+}
+
+// NewNodeRestartAction returns the tir_node_restart action.
+func NewNodeRestartAction() action.Action {
+	return &nodeAction{operation: "restart"}
+}
+
+// nodeActionModel maps the action configuration.
+type nodeActionModel struct {
+	NodeID         types.String `tfsdk:"node_id"`
+	ProjectID      types.String `tfsdk:"project_id"`
+	TeamID         types.String `tfsdk:"team_id"`
+	ActiveIAM      types.String `tfsdk:"active_iam"`
+	TimeoutMinutes types.Int64  `tfsdk:"timeout_minutes"`
+}
+
+func (a *nodeAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = "tir_node_" + a.operation
+}
+
+func (a *nodeAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: fmt.Sprintf("Requests a %s of a TIR node and waits until the node has settled.", a.operation),
+		Attributes: map[string]schema.Attribute{
+			"node_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the node, usually tir_node.<name>.id.",
+			},
+			"project_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the project where the node is deployed.",
+			},
+			"team_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the team that owns the node.",
+			},
+			"active_iam": schema.StringAttribute{
+				Required:    true,
+				Description: "The IAM (Identity and Access Management) role associated with the node.",
+			},
+			"timeout_minutes": schema.Int64Attribute{
+				Optional:    true,
+				Description: "How long to wait for the node to settle. Default is 20 minutes.",
+			},
+		},
+	}
+}
+
+func (a *nodeAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	apiClient, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("Expected *client.Client, got %T.", req.ProviderData))
+		return
+	}
+	a.client = apiClient
+}
+
+func (a *nodeAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var config nodeActionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	timeout := defaultNodeActionTimeout
+	if !config.TimeoutMinutes.IsNull() {
+		timeout = time.Duration(config.TimeoutMinutes.ValueInt64()) * time.Minute
+	}
+	nodeID := config.NodeID.ValueString()
+	projectID, teamID, activeIAM := config.ProjectID.ValueString(), config.TeamID.ValueString(), config.ActiveIAM.ValueString()
+
+	// transition asks TIR to stop (stop == true) or start the node and waits for the result.
+	transition := func(stop bool) error {
+		verb, target := "Starting", "running"
+		if stop {
+			verb, target = "Stopping", "stopped"
+		}
+		resp.SendProgress(action.InvokeProgressEvent{Message: fmt.Sprintf("%s node %s", verb, nodeID)})
+		if _, err := a.client.UpdateStartStopNode(nodeID, projectID, teamID, activeIAM, stop); err != nil {
+			return err
+		}
+		if err := waitForNodeStatus(ctx, a.client, nodeID, projectID, teamID, activeIAM, target, timeout); err != nil {
+			return err
+		}
+		resp.SendProgress(action.InvokeProgressEvent{Message: fmt.Sprintf("Node %s is %s", nodeID, target)})
+		return nil
+	}
+
+	var err error
+	switch a.operation {
+	case "stop":
+		err = transition(true)
+	case "start":
+		err = transition(false)
+	case "restart":
+		if err = transition(true); err == nil {
+			err = transition(false)
+		}
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to %s node %s", a.operation, nodeID), err.Error())
+	}
+}
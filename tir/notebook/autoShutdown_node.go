@@ -0,0 +1,212 @@
+package notebook
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	// Embedded so timezone is validated the same way on machines without a zoneinfo database.
+	_ "time/tzdata"
+
+	"github.com/e2eterraformprovider/terraform-provider-tir/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// defaultAutoShutdownTimezone is the timezone of the schedules when none is set, the one of the
+// TIR regions.
+const defaultAutoShutdownTimezone = "Asia/Kolkata"
+
+// cronField describes one field of a standard five field cron expression: the range of its
+// values and, for month and day of week, the names that stand for them.
+type cronField struct {
+	name     string
+	min, max int
+	names    []string
+	question bool
+}
+
+// cronFields are the fields of a cron expression, in order. Day of week 7 is Sunday, like 0.
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day-of-month", min: 1, max: 31, question: true},
+	{name: "month", min: 1, max: 12, names: []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}},
+	{name: "day-of-week", min: 0, max: 7, names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}, question: true},
+}
+
+// autoShutdownSchema returns the auto_shutdown block of a node.
+func autoShutdownSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Stops the node when it is idle or on a schedule, and starts it on a schedule. Not available for committed nodes, which cannot be stopped.",
+		Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+			"idle_minutes": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(15),
+				Description:  "Stops the node once it has been idle for this many minutes. At least 15.",
+			},
+			"schedule_stop_cron": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateCron,
+				Description:  "Stops the node on this five field cron schedule, for example '0 20 * * 1-5' for 8 PM on weekdays.",
+			},
+			"schedule_start_cron": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateCron,
+				Description:  "Starts the node on this five field cron schedule, for example '0 9 * * 1-5' for 9 AM on weekdays.",
+			},
+			"timezone": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      defaultAutoShutdownTimezone,
+				ValidateFunc: validateTimezone,
+				Description:  "The IANA timezone the schedules are in, for example 'Asia/Kolkata' or 'UTC'. Default is 'Asia/Kolkata'.",
+			},
+		}},
+	}
+}
+
+func validateCron(value interface{}, key string) ([]string, []error) {
+	fields := strings.Fields(value.(string))
+	if len(fields) != 5 {
+		return nil, []error{fmt.Errorf("%s must be a cron expression with five fields (minute hour day-of-month month day-of-week), got %q", key, value)}
+	}
+	for i, field := range fields {
+		if err := cronFields[i].validate(field); err != nil {
+			return nil, []error{fmt.Errorf("%s has an invalid %s field %q: %s", key, cronFields[i].name, field, err)}
+		}
+	}
+	return nil, nil
+}
+
+// validate checks a field made of comma separated items. Each item is '*', a value or a range
+// 'a-b', optionally followed by a step '/n'. '?' stands for any day of month or week.
+func (f cronField) validate(field string) error {
+	for _, item := range strings.Split(field, ",") {
+		if f.question && item == "?" {
+			continue
+		}
+		span, step, hasStep := strings.Cut(item, "/")
+		if hasStep {
+			if n, err := strconv.Atoi(step); err != nil || n < 1 || n > f.max {
+				return fmt.Errorf("step %q must be a number from 1 to %d", step, f.max)
+			}
+		}
+		if span == "*" {
+			continue
+		}
+		low, high, isRange := strings.Cut(span, "-")
+		start, err := f.value(low)
+		if err != nil {
+			return err
+		}
+		if !isRange {
+			continue
+		}
+		end, err := f.value(high)
+		if err != nil {
+			return err
+		}
+		if start > end {
+			return fmt.Errorf("range %q starts after it ends", span)
+		}
+	}
+	return nil
+}
+
+// value returns the number a value of the field stands for.
+func (f cronField) value(text string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(text, name) {
+			return i + f.min, nil
+		}
+	}
+	n, err := strconv.Atoi(text)
+	if err != nil || n < f.min || n > f.max {
+		if len(f.names) > 0 {
+			return 0, fmt.Errorf("%q must be a number from %d to %d or one of %s-%s", text, f.min, f.max, f.names[0], f.names[len(f.names)-1])
+		}
+		return 0, fmt.Errorf("%q must be a number from %d to %d", text, f.min, f.max)
+	}
+	return n, nil
+}
+
+func validateTimezone(value interface{}, key string) ([]string, []error) {
+	if _, err := time.LoadLocation(value.(string)); err != nil || value.(string) == "" {
+		return nil, []error{fmt.Errorf("%s must be an IANA timezone such as 'Asia/Kolkata' or 'UTC', got %q", key, value)}
+	}
+	return nil, nil
+}
+
+// expandAutoShutdown returns the auto shutdown settings of the node, or nil when the block is
+// not set.
+func expandAutoShutdown(d *schema.ResourceData) *models.NodeAutoShutdown {
+	blocks := d.Get("auto_shutdown").([]interface{})
+	if len(blocks) == 0 || blocks[0] == nil {
+		return nil
+	}
+	block := blocks[0].(map[string]interface{})
+	return &models.NodeAutoShutdown{
+		IdleMinutes:       block["idle_minutes"].(int),
+		ScheduleStopCron:  block["schedule_stop_cron"].(string),
+		ScheduleStartCron: block["schedule_start_cron"].(string),
+		Timezone:          block["timezone"].(string),
+	}
+}
+
+// flattenAutoShutdown returns the auto_shutdown block of the settings GetNode reports, which are
+// null when auto shutdown is off.
+func flattenAutoShutdown(value interface{}) []interface{} {
+	settings, ok := value.(map[string]interface{})
+	if !ok {
+		return []interface{}{}
+	}
+	idleMinutes, _ := settings["idle_minutes"].(float64)
+	stopCron, _ := settings["schedule_stop_cron"].(string)
+	startCron, _ := settings["schedule_start_cron"].(string)
+	timezone, _ := settings["timezone"].(string)
+	if idleMinutes == 0 && stopCron == "" && startCron == "" {
+		return []interface{}{}
+	}
+	if timezone == "" {
+		timezone = defaultAutoShutdownTimezone
+	}
+	return []interface{}{map[string]interface{}{
+		"idle_minutes":        int(idleMinutes),
+		"schedule_stop_cron":  stopCron,
+		"schedule_start_cron": startCron,
+		"timezone":            timezone,
+	}}
+}
+
+// customizeNodeAutoShutdownDiff rejects auto_shutdown on committed nodes, which cannot be
+// stopped, and an auto_shutdown block that would do nothing.
+func customizeNodeAutoShutdownDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() || !config.Type().IsObjectType() {
+		return nil
+	}
+	blocks := config.GetAttr("auto_shutdown")
+	if blocks.IsNull() || !blocks.IsKnown() || blocks.LengthInt() == 0 {
+		return nil
+	}
+	if d.NewValueKnown("sku_type") && d.Get("sku_type").(string) == "committed" {
+		return fmt.Errorf("auto_shutdown cannot be set on a committed node, committed nodes cannot be stopped")
+	}
+	for it := blocks.ElementIterator(); it.Next(); {
+		_, block := it.Element()
+		for _, key := range []string{"idle_minutes", "schedule_stop_cron", "schedule_start_cron"} {
+			if !block.GetAttr(key).IsNull() {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("auto_shutdown needs at least one of idle_minutes, schedule_stop_cron and schedule_start_cron")
+}
@@ -0,0 +1,68 @@
+package notebook
+
+import "testing"
+
+func TestValidateCron(t *testing.T) {
+	cases := []struct {
+		cron    string
+		wantErr bool
+	}{
+		{cron: "0 20 * * 1-5", wantErr: false},
+		{cron: "*/15 9-18 * * MON-FRI", wantErr: false},
+		{cron: "0 0 1,15 * ?", wantErr: false},
+		{cron: "  0 9   * * *  ", wantErr: false},
+		{cron: "0 0 * JAN-DEC *", wantErr: false},
+		{cron: "0 0 1 jan sun", wantErr: false},
+		{cron: "59 23 31 12 7", wantErr: false},
+		{cron: "0 8-18/2 * * *", wantErr: false},
+		{cron: "0 20 * *", wantErr: true},
+		{cron: "99 99 99 99 99", wantErr: true},
+		{cron: "a b c d e", wantErr: true},
+		{cron: "60 * * * *", wantErr: true},
+		{cron: "0 24 * * *", wantErr: true},
+		{cron: "0 0 0 * *", wantErr: true},
+		{cron: "0 0 32 * *", wantErr: true},
+		{cron: "0 0 * 13 *", wantErr: true},
+		{cron: "0 0 * 0 *", wantErr: true},
+		{cron: "0 0 * * 8", wantErr: true},
+		{cron: "0 0 * * FUN", wantErr: true},
+		{cron: "*/0 * * * *", wantErr: true},
+		{cron: "30-10 * * * *", wantErr: true},
+		{cron: "? * * * *", wantErr: true},
+		{cron: "0,,30 * * * *", wantErr: true},
+		{cron: "0 20 * * * *", wantErr: true},
+		{cron: "@daily", wantErr: true},
+		{cron: "0 20 * * 1;5", wantErr: true},
+		{cron: "", wantErr: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.cron, func(t *testing.T) {
+			_, errs := validateCron(tc.cron, "schedule_stop_cron")
+			if (len(errs) > 0) != tc.wantErr {
+				t.Errorf("validateCron(%q) errors = %v, want error %t", tc.cron, errs, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateTimezone(t *testing.T) {
+	cases := []struct {
+		timezone string
+		wantErr  bool
+	}{
+		{timezone: "Asia/Kolkata", wantErr: false},
+		{timezone: "UTC", wantErr: false},
+		{timezone: "America/New_York", wantErr: false},
+		{timezone: "IST+5:30", wantErr: true},
+		{timezone: "Asia/Nowhere", wantErr: true},
+		{timezone: "", wantErr: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.timezone, func(t *testing.T) {
+			_, errs := validateTimezone(tc.timezone, "timezone")
+			if (len(errs) > 0) != tc.wantErr {
+				t.Errorf("validateTimezone(%q) errors = %v, want error %t", tc.timezone, errs, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package notebook
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// defaultSSHPort and defaultSSHUser are used when TIR does not report the SSH endpoint of a
+// node explicitly, which is the case for nodes reachable directly on their public IP.
+const (
+	defaultSSHPort = 22
+	defaultSSHUser = "root"
+)
+
+// connectionSchema returns the computed attributes describing how to reach a node.
+func connectionSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"lab_url": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The URL of the JupyterLab of the node. Empty when JupyterLab is disabled.",
+		},
+		"public_ip": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The public IP address of the node, when it has one.",
+		},
+		"ssh_host": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The host to connect to with SSH. Empty when enable_ssh is false.",
+		},
+		"ssh_port": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The port to connect to with SSH. Zero when enable_ssh is false.",
+		},
+		"ssh_user": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The user to log in as with SSH. Empty when enable_ssh is false.",
+		},
+		"ssh_command": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "A ready to use SSH command for the node, for example 'ssh -p 22 root@203.0.113.10'. Empty when enable_ssh is false.",
+		},
+	}
+}
+
+// nodeConnectionDetails are the values of the connection attributes of a node.
+type nodeConnectionDetails struct {
+	labURL     string
+	publicIP   string
+	sshHost    string
+	sshPort    int
+	sshUser    string
+	sshCommand string
+}
+
+// nodeConnection reads the connection details of a node from its GetNode data. The SSH details
+// are left empty when enableSSH is false.
+func nodeConnection(data map[string]interface{}, enableSSH bool) nodeConnectionDetails {
+	var details nodeConnectionDetails
+	details.labURL, _ = data["lab_url"].(string)
+	details.publicIP, _ = data["public_ip"].(string)
+	if enableSSH {
+		sshDetails, _ := data["ssh_details"].(map[string]interface{})
+		details.sshHost = firstString(sshDetails["host"], details.publicIP)
+		details.sshUser = firstString(sshDetails["user"], sshDetails["username"], defaultSSHUser)
+		details.sshPort = defaultSSHPort
+		switch port := sshDetails["port"].(type) {
+		case float64:
+			details.sshPort = int(port)
+		case string:
+			if value, err := strconv.Atoi(port); err == nil {
+				details.sshPort = value
+			}
+		}
+	}
+	if details.sshHost != "" {
+		details.sshCommand = fmt.Sprintf("ssh -p %d %s@%s", details.sshPort, details.sshUser, details.sshHost)
+	}
+	return details
+}
+
+// setNodeConnection records the connection details of a node from its GetNode data.
+func setNodeConnection(d *schema.ResourceData, data map[string]interface{}) {
+	details := nodeConnection(data, d.Get("enable_ssh").(bool))
+	d.Set("lab_url", details.labURL)
+	d.Set("public_ip", details.publicIP)
+	d.Set("ssh_host", details.sshHost)
+	d.Set("ssh_port", details.sshPort)
+	d.Set("ssh_user", details.sshUser)
+	d.Set("ssh_command", details.sshCommand)
+}
+
+// firstString returns the first of values that is a non empty string.
+func firstString(values ...interface{}) string {
+	for _, value := range values {
+		if text, ok := value.(string); ok && text != "" {
+			return text
+		}
+	}
+	return ""
+}
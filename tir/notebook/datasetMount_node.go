@@ -0,0 +1,156 @@
+package notebook
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/e2eterraformprovider/terraform-provider-tir/client"
+	"github.com/e2eterraformprovider/terraform-provider-tir/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// datasetIDPattern matches the IDs of datasets, as exported by tir_eos.
+var datasetIDPattern = regexp.MustCompile(`^[0-9]+$`)
+
+// datasetMountSchema returns the dataset_mount block of a node.
+func datasetMountSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeSet,
+		Optional:      true,
+		ConflictsWith: []string{"dataset_id_list"},
+		Description:   "A dataset mounted inside the node. Mounts added, removed or changed here are attached to or detached from the node in place. When moving from dataset_id_list, the datasets it mounted are matched against these blocks, so they are not mounted twice.",
+		Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+			"dataset_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringMatch(datasetIDPattern, "must be the numeric ID of a dataset, for example tir_eos.<name>.id"),
+				Description:  "The ID of the dataset to mount, for example tir_eos.<name>.id.",
+			},
+			"mount_path": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^/`), "must be an absolute path"),
+				Description:  "The absolute path the dataset is mounted at inside the node, for example '/datasets/train'.",
+			},
+			"read_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Mounts the dataset read only. Default is false.",
+			},
+		}},
+	}
+}
+
+// expandDatasetMounts converts dataset_mount blocks to the mounts the API takes. The dataset IDs
+// are validated at plan time, so every ID converts.
+func expandDatasetMounts(mounts []interface{}) []models.NodeDatasetMount {
+	result := make([]models.NodeDatasetMount, 0, len(mounts))
+	for _, item := range mounts {
+		mount := item.(map[string]interface{})
+		datasetID, _ := strconv.Atoi(mount["dataset_id"].(string))
+		result = append(result, models.NodeDatasetMount{
+			DatasetID: datasetID,
+			MountPath: mount["mount_path"].(string),
+			ReadOnly:  mount["read_only"].(bool),
+		})
+	}
+	return result
+}
+
+// flattenDatasetMounts returns the dataset_mount blocks of the mounts GetNode reports.
+func flattenDatasetMounts(mounts []interface{}) []map[string]interface{} {
+	result := []map[string]interface{}{}
+	for _, item := range mounts {
+		mount, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		datasetID, ok := mount["dataset_id"].(float64)
+		if !ok {
+			continue
+		}
+		mountPath, _ := mount["mount_path"].(string)
+		readOnly, _ := mount["read_only"].(bool)
+		result = append(result, map[string]interface{}{
+			"dataset_id": strconv.Itoa(int(datasetID)),
+			"mount_path": mountPath,
+			"read_only":  readOnly,
+		})
+	}
+	return result
+}
+
+// updateNodeDatasetMounts detaches the mounts removed from dataset_mount and attaches the added
+// ones. A mount whose path or read_only changed is detached and attached again. Detaching comes
+// first, so a path can move from one dataset to another in a single apply. When the node moves
+// from dataset_id_list to dataset_mount, the mounts are compared with the ones TIR reports, as
+// the state holds none of them.
+func updateNodeDatasetMounts(d *schema.ResourceData, apiClient *client.Client) error {
+	nodeID := d.Id()
+	projectID := d.Get("project_id").(string)
+	teamID := d.Get("team_id").(string)
+	activeIAM := d.Get("active_iam").(string)
+	oldMounts, newMounts := d.GetChange("dataset_mount")
+	current := oldMounts.(*schema.Set)
+	if oldList, _ := d.GetChange("dataset_id_list"); len(oldList.([]interface{})) > 0 {
+		response, err := apiClient.GetNode(nodeID, projectID, teamID, activeIAM)
+		if err != nil {
+			return err
+		}
+		data, _ := response["data"].(map[string]interface{})
+		mounts, _ := data["dataset_mounts"].([]interface{})
+		current = schema.NewSet(current.F, nil)
+		for _, mount := range flattenDatasetMounts(mounts) {
+			current.Add(mount)
+		}
+	}
+	detach := expandDatasetMounts(current.Difference(newMounts.(*schema.Set)).List())
+	attach := expandDatasetMounts(newMounts.(*schema.Set).Difference(current).List())
+	if len(detach) > 0 {
+		if err := apiClient.UpdateNodeDatasets(nodeID, projectID, teamID, activeIAM, detach, false); err != nil {
+			return err
+		}
+	}
+	if len(attach) > 0 {
+		if err := apiClient.UpdateNodeDatasets(nodeID, projectID, teamID, activeIAM, attach, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// customizeNodeDatasetMountDiff rejects mounting a dataset twice or two datasets at the same
+// path, which TIR refuses only once the node is being created or updated. It reads the raw
+// config, so mounts of datasets created in the same apply are checked as far as they are known.
+func customizeNodeDatasetMountDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() || !config.Type().IsObjectType() {
+		return nil
+	}
+	mounts := config.GetAttr("dataset_mount")
+	if mounts.IsNull() || !mounts.IsKnown() {
+		return nil
+	}
+	datasetIDs := map[string]bool{}
+	mountPaths := map[string]bool{}
+	for it := mounts.ElementIterator(); it.Next(); {
+		_, mount := it.Element()
+		if datasetID := mount.GetAttr("dataset_id"); datasetID.IsKnown() && !datasetID.IsNull() {
+			if datasetIDs[datasetID.AsString()] {
+				return fmt.Errorf("dataset %s is mounted more than once, use a single dataset_mount block per dataset", datasetID.AsString())
+			}
+			datasetIDs[datasetID.AsString()] = true
+		}
+		if mountPath := mount.GetAttr("mount_path"); mountPath.IsKnown() && !mountPath.IsNull() {
+			if mountPaths[mountPath.AsString()] {
+				return fmt.Errorf("more than one dataset is mounted at %s, every dataset_mount needs its own mount_path", mountPath.AsString())
+			}
+			mountPaths[mountPath.AsString()] = true
+		}
+	}
+	return nil
+}
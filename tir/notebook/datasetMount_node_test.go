@@ -0,0 +1,90 @@
+package notebook
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReadNodeDatasetMounts(t *testing.T) {
+	cases := []struct {
+		name          string
+		datasetIDList []interface{}
+		wantMounts    int
+	}{
+		{
+			name:       "mounts are read",
+			wantMounts: 1,
+		},
+		{
+			name:          "mounts of dataset_id_list are not read",
+			datasetIDList: []interface{}{"7"},
+			wantMounts:    0,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			f, apiClient := newFakeTIR(t)
+			f.node["dataset_mounts"] = []interface{}{
+				map[string]interface{}{"dataset_id": 7, "mount_path": "/datasets/7", "read_only": false},
+			}
+			state := nodeTestState(t, nodeTestConfig(map[string]interface{}{"dataset_id_list": tc.datasetIDList}))
+			refreshed := readNode(t, apiClient, state)
+			if got := countOf(refreshed, "dataset_mount"); got != tc.wantMounts {
+				t.Errorf("%d dataset_mount blocks, want %d", got, tc.wantMounts)
+			}
+		})
+	}
+}
+
+func TestUpdateNodeDatasetMounts(t *testing.T) {
+	mount := func(datasetID string, mountPath string) map[string]interface{} {
+		return map[string]interface{}{"dataset_id": datasetID, "mount_path": mountPath, "read_only": false}
+	}
+	cases := []struct {
+		name      string
+		state     map[string]interface{}
+		config    map[string]interface{}
+		wantCalls []string
+	}{
+		{
+			name:      "mount added",
+			state:     map[string]interface{}{"dataset_mount": []interface{}{mount("7", "/datasets/7")}},
+			config:    map[string]interface{}{"dataset_mount": []interface{}{mount("7", "/datasets/7"), mount("8", "/datasets/8")}},
+			wantCalls: []string{"attach_datasets"},
+		},
+		{
+			name:      "mount moved",
+			state:     map[string]interface{}{"dataset_mount": []interface{}{mount("7", "/datasets/7")}},
+			config:    map[string]interface{}{"dataset_mount": []interface{}{mount("7", "/data")}},
+			wantCalls: []string{"detach_datasets", "attach_datasets"},
+		},
+		{
+			name:      "moving from dataset_id_list keeps its mounts",
+			state:     map[string]interface{}{"dataset_id_list": []interface{}{"7"}},
+			config:    map[string]interface{}{"dataset_mount": []interface{}{mount("7", "/datasets/7")}},
+			wantCalls: nil,
+		},
+		{
+			name:      "moving from dataset_id_list with a new mount",
+			state:     map[string]interface{}{"dataset_id_list": []interface{}{"7"}},
+			config:    map[string]interface{}{"dataset_mount": []interface{}{mount("7", "/datasets/7"), mount("8", "/datasets/8")}},
+			wantCalls: []string{"attach_datasets"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			f, apiClient := newFakeTIR(t)
+			f.node["dataset_mounts"] = []interface{}{
+				map[string]interface{}{"dataset_id": 7, "mount_path": "/datasets/7", "read_only": false},
+			}
+			state := nodeTestState(t, nodeTestConfig(tc.state))
+			_, diags := applyNode(t, apiClient, state, nodeTestConfig(tc.config))
+			if diags.HasError() {
+				t.Fatalf("apply: %v", diags)
+			}
+			if !reflect.DeepEqual(f.calls, tc.wantCalls) {
+				t.Errorf("calls = %v, want %v", f.calls, tc.wantCalls)
+			}
+		})
+	}
+}
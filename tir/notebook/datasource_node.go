@@ -0,0 +1,172 @@
+package notebook
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/e2eterraformprovider/terraform-provider-tir/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// nodeDataSchema returns the computed attributes describing an existing node, shared by the
+// tir_node and tir_nodes data sources.
+func nodeDataSchema() map[string]*schema.Schema {
+	computed := func(valueType schema.ValueType, description string) *schema.Schema {
+		return &schema.Schema{Type: valueType, Computed: true, Description: description}
+	}
+	return map[string]*schema.Schema{
+		"node_name":     computed(schema.TypeString, "The name of the node."),
+		"status":        computed(schema.TypeString, "The current status of the node, for example 'running' or 'stopped'."),
+		"sku_name":      computed(schema.TypeString, "The SKU name of the plan of the node."),
+		"sku_type":      computed(schema.TypeString, "The SKU type of the plan of the node, 'hourly' or 'committed'."),
+		"image_type":    computed(schema.TypeString, "The type of image of the node, 'pre-built' or 'custom'."),
+		"image_name":    computed(schema.TypeString, "The name of the pre-built image of the node."),
+		"image_version": computed(schema.TypeString, "The version of the pre-built image of the node."),
+		"image_url":     computed(schema.TypeString, "The image reference of a node running a custom image."),
+		"disk_size":     computed(schema.TypeInt, "The size of the disk of the node in GB."),
+		"created_at":    computed(schema.TypeString, "The timestamp when the node was created."),
+		"labels": {
+			Type:        schema.TypeMap,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "The labels of the node.",
+		},
+		"enable_ssh":  computed(schema.TypeBool, "Whether SSH access is enabled for the node."),
+		"lab_url":     computed(schema.TypeString, "The URL of the JupyterLab of the node. Empty when JupyterLab is disabled."),
+		"public_ip":   computed(schema.TypeString, "The public IP address of the node, when it has one."),
+		"ssh_host":    computed(schema.TypeString, "The host to connect to with SSH. Empty when SSH is disabled."),
+		"ssh_port":    computed(schema.TypeInt, "The port to connect to with SSH. Zero when SSH is disabled."),
+		"ssh_user":    computed(schema.TypeString, "The user to log in as with SSH. Empty when SSH is disabled."),
+		"ssh_command": computed(schema.TypeString, "A ready to use SSH command for the node. Empty when SSH is disabled."),
+	}
+}
+
+// flattenNode returns the values of the nodeDataSchema attributes of a node from its GetNode
+// data or from an item of ListNodes.
+func flattenNode(data map[string]interface{}) map[string]interface{} {
+	imageDetails, _ := data["image_details"].(map[string]interface{})
+	skuDetails, _ := data["sku_details"].(map[string]interface{})
+	specs, _ := skuDetails["specs"].(map[string]interface{})
+	plan, _ := skuDetails["plan"].(map[string]interface{})
+	imageType := firstString(imageDetails["image_type"], imageTypePreBuilt)
+	diskSize, _ := data["disk_size_in_gb"].(float64)
+	enableSSH, _ := data["enable_ssh"].(bool)
+	nodeLabels := map[string]interface{}{}
+	if remote, ok := data["labels"].(map[string]interface{}); ok {
+		for key, value := range remote {
+			nodeLabels[key] = fmt.Sprint(value)
+		}
+	}
+	connection := nodeConnection(data, enableSSH)
+	return map[string]interface{}{
+		"node_name":     firstString(data["name"]),
+		"status":        firstString(data["status"]),
+		"sku_name":      firstString(specs["name"]),
+		"sku_type":      firstString(plan["sku_type"]),
+		"image_type":    imageType,
+		"image_name":    firstString(imageDetails["name"]),
+		"image_version": firstString(imageDetails["version"]),
+		"image_url":     firstString(imageDetails["image_url"]),
+		"disk_size":     int(diskSize),
+		"created_at":    firstString(data["created_at"]),
+		"labels":        nodeLabels,
+		"enable_ssh":    enableSSH,
+		"lab_url":       connection.labURL,
+		"public_ip":     connection.publicIP,
+		"ssh_host":      connection.sshHost,
+		"ssh_port":      connection.sshPort,
+		"ssh_user":      connection.sshUser,
+		"ssh_command":   connection.sshCommand,
+	}
+}
+
+// nodeID returns the ID of a node from its GetNode data or ListNodes item.
+func nodeID(data map[string]interface{}) string {
+	switch id := data["id"].(type) {
+	case float64:
+		return strconv.Itoa(int(id))
+	case string:
+		return id
+	}
+	return ""
+}
+
+// DataSourceNode looks up an existing node by ID or by its name in a project, so other
+// configurations can use a node they do not manage.
+func DataSourceNode() *schema.Resource {
+	dataSchema := nodeDataSchema()
+	dataSchema["id"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"id", "node_name"},
+		Description:  "The ID of the node. Either id or node_name must be set.",
+	}
+	dataSchema["node_name"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"id", "node_name"},
+		Description:  "The name of the node, unique within the project. Either id or node_name must be set.",
+	}
+	for key, description := range map[string]string{
+		"project_id": "The ID of the project the node is in.",
+		"team_id":    "The ID of the team the project belongs to.",
+		"active_iam": "The IAM used to reach the node.",
+	} {
+		dataSchema[key] = &schema.Schema{Type: schema.TypeString, Required: true, Description: description}
+	}
+	return &schema.Resource{
+		Schema:      dataSchema,
+		ReadContext: dataSourceNodeRead,
+	}
+}
+
+func dataSourceNodeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+	projectID := d.Get("project_id").(string)
+	teamID := d.Get("team_id").(string)
+	activeIAM := d.Get("active_iam").(string)
+
+	id := d.Get("id").(string)
+	if id == "" {
+		name := d.Get("node_name").(string)
+		items, err := apiClient.ListNodes(projectID, teamID, activeIAM)
+		if err != nil {
+			return diag.Errorf("Not able to list the nodes of project %s: %s", projectID, err)
+		}
+		var matches []string
+		for _, item := range items {
+			node, ok := item.(map[string]interface{})
+			if ok && node["name"] == name {
+				matches = append(matches, nodeID(node))
+			}
+		}
+		switch len(matches) {
+		case 0:
+			return diag.Errorf("No node named %q found in project %s", name, projectID)
+		case 1:
+			id = matches[0]
+		default:
+			return diag.Errorf("%d nodes named %q found in project %s, look the node up by id instead", len(matches), name, projectID)
+		}
+	}
+
+	response, err := apiClient.GetNode(id, projectID, teamID, activeIAM)
+	if err != nil {
+		if client.IsNotFound(err) {
+			return diag.Errorf("No node with ID %s found in project %s", id, projectID)
+		}
+		return diag.Errorf("Error finding node with id: %s - %v", id, err)
+	}
+	data := response["data"].(map[string]interface{})
+	for key, value := range flattenNode(data) {
+		if err := d.Set(key, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	d.SetId(id)
+	return nil
+}
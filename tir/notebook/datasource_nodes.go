@@ -0,0 +1,134 @@
+package notebook
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/e2eterraformprovider/terraform-provider-tir/client"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/labels"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// DataSourceNodes lists the nodes of a project, optionally filtered.
+func DataSourceNodes() *schema.Resource {
+	nodeSchema := nodeDataSchema()
+	nodeSchema["id"] = &schema.Schema{Type: schema.TypeString, Computed: true, Description: "The ID of the node."}
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the project whose nodes are listed.",
+			},
+			"team_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the team the project belongs to.",
+			},
+			"active_iam": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The IAM used to reach the nodes.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only nodes with this status are returned, for example 'running' or 'stopped'.",
+			},
+			"sku_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"hourly", "committed"}, false),
+				Description:  "Only nodes on this SKU type, 'hourly' or 'committed', are returned.",
+			},
+			"image_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only nodes running this image are returned. It is compared with the pre-built image name and with the image reference of custom images.",
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+				Description:  "Only nodes whose name matches this regular expression are returned.",
+			},
+			"labels": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Only nodes carrying all of these labels are returned.",
+			},
+			"ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The IDs of the returned nodes.",
+			},
+			"nodes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Resource{Schema: nodeSchema},
+				Description: "The returned nodes.",
+			},
+		},
+		ReadContext: dataSourceNodesRead,
+	}
+}
+
+func dataSourceNodesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+	projectID := d.Get("project_id").(string)
+	teamID := d.Get("team_id").(string)
+	activeIAM := d.Get("active_iam").(string)
+	status := d.Get("status").(string)
+	skuType := d.Get("sku_type").(string)
+	imageName := d.Get("image_name").(string)
+	var nameRegex *regexp.Regexp
+	if pattern, ok := d.GetOk("name_regex"); ok {
+		nameRegex = regexp.MustCompile(pattern.(string))
+	}
+	wantLabels := map[string]string{}
+	for key, value := range d.Get("labels").(map[string]interface{}) {
+		wantLabels[key] = value.(string)
+	}
+
+	items, err := apiClient.ListNodes(projectID, teamID, activeIAM)
+	if err != nil {
+		return diag.Errorf("Not able to list the nodes of project %s: %s", projectID, err)
+	}
+	ids := []string{}
+	nodes := []map[string]interface{}{}
+	for _, item := range items {
+		data, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		node := flattenNode(data)
+		switch {
+		case status != "" && !strings.EqualFold(node["status"].(string), status):
+			continue
+		case skuType != "" && node["sku_type"] != skuType:
+			continue
+		case imageName != "" && node["image_name"] != imageName && node["image_url"] != imageName:
+			continue
+		case nameRegex != nil && !nameRegex.MatchString(node["node_name"].(string)):
+			continue
+		case !labels.Matches(data["labels"], wantLabels):
+			continue
+		}
+		node["id"] = nodeID(data)
+		ids = append(ids, node["id"].(string))
+		nodes = append(nodes, node)
+	}
+	if err := d.Set("ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("nodes", nodes); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("nodes" + teamID + projectID + activeIAM)
+	return nil
+}
@@ -0,0 +1,147 @@
+package notebook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/e2eterraformprovider/terraform-provider-tir/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// fakeTIR serves the node API calls of tir_node for tests. GetNode returns node, every other
+// call is recorded under a short name, for example "stop" or "attach_datasets", and succeeds
+// unless that name is in fail.
+type fakeTIR struct {
+	mu    sync.Mutex
+	node  map[string]interface{}
+	fail  map[string]bool
+	calls []string
+}
+
+// newFakeTIR starts a fakeTIR for a running hourly node 5 in project p of team t, and returns
+// a Client that talks to it.
+func newFakeTIR(t *testing.T) (*fakeTIR, *client.Client) {
+	t.Helper()
+	f := &fakeTIR{
+		node: map[string]interface{}{
+			"id":            5,
+			"name":          "node",
+			"status":        "running",
+			"created_at":    "2026-01-01T00:00:00Z",
+			"image_details": map[string]interface{}{"name": "img", "version": "v1"},
+			"sku_details": map[string]interface{}{
+				"specs": map[string]interface{}{"name": "C3.8GB"},
+				"plan":  map[string]interface{}{"sku_type": "hourly", "currency": "INR"},
+			},
+		},
+		fail: map[string]bool{},
+	}
+	server := httptest.NewServer(f)
+	t.Cleanup(server.Close)
+	previous := nodePollInterval
+	nodePollInterval = 0
+	t.Cleanup(func() { nodePollInterval = previous })
+	return f, client.NewClient("key", "token", server.URL)
+}
+
+func (f *fakeTIR) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	io.Copy(io.Discard, r.Body)
+	if r.Method == http.MethodGet {
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": f.node})
+		return
+	}
+	name := r.URL.Query().Get("action")
+	switch {
+	case r.Method == http.MethodPatch:
+		name = "labels"
+	case strings.HasSuffix(r.URL.Path, "/image_update/"):
+		name = "image"
+	case strings.HasSuffix(r.URL.Path, "/auto-shutdown/"):
+		name = "auto_shutdown"
+	case !strings.HasSuffix(r.URL.Path, "/actions/"):
+		name = "plan"
+	case name == "":
+		name = "rename"
+	}
+	f.calls = append(f.calls, name)
+	if f.fail[name] {
+		w.WriteHeader(http.StatusBadRequest)
+		io.WriteString(w, `{"message":"refused by the fake"}`)
+		return
+	}
+	switch name {
+	case "stop":
+		f.node["status"] = "stopped"
+	case "start":
+		f.node["status"] = "running"
+	}
+	io.WriteString(w, `{"data":{}}`)
+}
+
+// nodeTestConfig returns the arguments of node 5 as served by newFakeTIR, with overrides.
+func nodeTestConfig(overrides map[string]interface{}) map[string]interface{} {
+	config := map[string]interface{}{
+		"node_name":     "node",
+		"image_name":    "img",
+		"image_version": "v1",
+		"sku_name":      "C3.8GB",
+		"sku_type":      "hourly",
+		"currency":      "INR",
+		"location":      "Delhi",
+		"project_id":    "p",
+		"team_id":       "t",
+		"active_iam":    "i",
+		"instance_type": "paid_usage",
+	}
+	for key, value := range overrides {
+		config[key] = value
+	}
+	return config
+}
+
+// nodeTestState returns the state of node 5 created from config, defaults included.
+func nodeTestState(t *testing.T, config map[string]interface{}) *terraform.InstanceState {
+	t.Helper()
+	d := schema.TestResourceDataRaw(t, ResourceNode().SchemaMap(), config)
+	d.SetId("5")
+	d.Set("status", "running")
+	return d.State()
+}
+
+// applyNode plans config against state and applies the plan, as Terraform does for an update.
+func applyNode(t *testing.T, apiClient *client.Client, state *terraform.InstanceState, config map[string]interface{}) (*terraform.InstanceState, diag.Diagnostics) {
+	t.Helper()
+	r := ResourceNode()
+	diff, err := schema.InternalMap(r.SchemaMap()).Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), nil, apiClient, false)
+	if err != nil {
+		t.Fatalf("planning: %v", err)
+	}
+	return r.Apply(context.Background(), state, diff, apiClient)
+}
+
+// readNode refreshes state from the fake.
+func readNode(t *testing.T, apiClient *client.Client, state *terraform.InstanceState) *terraform.InstanceState {
+	t.Helper()
+	refreshed, diags := ResourceNode().RefreshWithoutUpgrade(context.Background(), state, apiClient)
+	if diags.HasError() {
+		t.Fatalf("reading: %v", diags)
+	}
+	return refreshed
+}
+
+// countOf returns the number of elements of the list or set attribute key in state.
+func countOf(state *terraform.InstanceState, key string) int {
+	count, _ := strconv.Atoi(state.Attributes[key+".#"])
+	return count
+}
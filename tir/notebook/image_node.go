@@ -0,0 +1,138 @@
+package notebook
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/e2eterraformprovider/terraform-provider-tir/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Image types of a node. Pre-built images come from the TIR catalog listed by tir_node_images,
+// custom images are container images of the user's own.
+const (
+	imageTypePreBuilt = "pre-built"
+	imageTypeCustom   = "custom"
+)
+
+// nodeImageKeys are the attributes applied by an image update.
+var nodeImageKeys = []string{"image_type", "image_name", "image_version", "custom_image"}
+
+// customImageSchema returns the custom_image block of a node.
+func customImageSchema() *schema.Schema {
+	numericID := validation.StringMatch(regexp.MustCompile(`^[0-9]+$`), "must be a numeric ID")
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "The container image of the node when image_type is 'custom'.",
+		Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+			"image_url": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[^\s]+$`), "must be an image reference without spaces"),
+				Description:  "The image to run, with its registry and tag, for example 'registry.e2enetworks.net/my-namespace/pytorch-cuda:12.1'.",
+			},
+			"registry_namespace_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  numericID,
+				ConflictsWith: []string{"custom_image.0.registry_integration_id"},
+				Description:   "The ID of the TIR container registry namespace the image is pulled from.",
+			},
+			"registry_integration_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  numericID,
+				ConflictsWith: []string{"custom_image.0.registry_namespace_id"},
+				Description:   "The ID of the integration holding the credentials of the private registry the image is pulled from.",
+			},
+			"startup_command": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The command run when the node starts. The entrypoint of the image is used when empty.",
+			},
+		}},
+	}
+}
+
+// expandCustomImage returns the custom image of the node, or nil for a pre-built image.
+func expandCustomImage(d *schema.ResourceData) *models.NodeCustomImage {
+	if d.Get("image_type").(string) != imageTypeCustom {
+		return nil
+	}
+	blocks := d.Get("custom_image").([]interface{})
+	if len(blocks) == 0 || blocks[0] == nil {
+		return nil
+	}
+	block := blocks[0].(map[string]interface{})
+	namespaceID, _ := strconv.Atoi(block["registry_namespace_id"].(string))
+	integrationID, _ := strconv.Atoi(block["registry_integration_id"].(string))
+	return &models.NodeCustomImage{
+		ImageURL:              block["image_url"].(string),
+		RegistryNamespaceID:   namespaceID,
+		RegistryIntegrationID: integrationID,
+		StartupCommand:        block["startup_command"].(string),
+	}
+}
+
+// setNodeImage records the image of a node from the image_details of its GetNode data. The
+// registry references of a custom image are not reported back and keep their configured values.
+func setNodeImage(d *schema.ResourceData, imageDetails map[string]interface{}) {
+	if imageType, ok := imageDetails["image_type"].(string); ok && imageType != "" {
+		d.Set("image_type", imageType)
+	}
+	if d.Get("image_type").(string) != imageTypeCustom {
+		d.Set("image_name", imageDetails["name"])
+		d.Set("image_version", imageDetails["version"])
+		return
+	}
+	imageURL, ok := imageDetails["image_url"].(string)
+	if !ok {
+		return
+	}
+	block := map[string]interface{}{
+		"image_url":               imageURL,
+		"registry_namespace_id":   d.Get("custom_image.0.registry_namespace_id"),
+		"registry_integration_id": d.Get("custom_image.0.registry_integration_id"),
+		"startup_command":         d.Get("custom_image.0.startup_command"),
+	}
+	if startupCommand, ok := imageDetails["startup_command"].(string); ok {
+		block["startup_command"] = startupCommand
+	}
+	d.Set("custom_image", []interface{}{block})
+}
+
+// customizeNodeImageDiff checks that the image attributes match image_type: a pre-built image is
+// picked with image_name and image_version, a custom image is described by custom_image.
+func customizeNodeImageDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("image_type") {
+		return nil
+	}
+	imageType := d.Get("image_type").(string)
+	hasCustomImage := len(d.Get("custom_image").([]interface{})) > 0
+	switch imageType {
+	case imageTypeCustom:
+		if !hasCustomImage && d.NewValueKnown("custom_image") {
+			return fmt.Errorf("a custom_image block is required when image_type is %q", imageTypeCustom)
+		}
+		for _, key := range []string{"image_name", "image_version"} {
+			if d.NewValueKnown(key) && d.Get(key).(string) != "" {
+				return fmt.Errorf("%s cannot be set when image_type is %q, the image is set by custom_image", key, imageTypeCustom)
+			}
+		}
+	default:
+		if hasCustomImage {
+			return fmt.Errorf("custom_image can only be set when image_type is %q", imageTypeCustom)
+		}
+		for _, key := range []string{"image_name", "image_version"} {
+			if d.NewValueKnown(key) && d.Get(key).(string) == "" {
+				return fmt.Errorf("%s is required when image_type is %q, see the tir_node_images data source", key, imageType)
+			}
+		}
+	}
+	return nil
+}
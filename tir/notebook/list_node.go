@@ -0,0 +1,12 @@
+package notebook
+
+import (
+	"github.com/e2eterraformprovider/terraform-provider-tir/client"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/listing"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+)
+
+// NewNodeListResource returns the tir_node list resource used by `terraform query`.
+func NewNodeListResource() list.ListResource {
+	return listing.New("tir_node", "node", "node_name", ResourceNode, (*client.Client).ListNodes)
+}
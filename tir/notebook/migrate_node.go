@@ -0,0 +1,220 @@
+package notebook
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// nodeStateUpgraders returns the upgraders that bring older tir_node state to the current
+// SchemaVersion.
+func nodeStateUpgraders() []schema.StateUpgrader {
+	return []schema.StateUpgrader{
+		{
+			Version: 0,
+			Type:    resourceNodeV0().CoreConfigSchema().ImpliedType(),
+			Upgrade: upgradeNodeStateV0,
+		},
+	}
+}
+
+// upgradeNodeStateV0 records the move of SSH keys from public to ssh_key_ids. Version 0 only had
+// public, the raw keys sent when the node was created. They stay in public: they have no saved key
+// IDs, and listing keys in ssh_key_ids that the configuration does not list would detach them on
+// the next apply. ssh_key_ids starts empty, so only keys added to it later are managed.
+func upgradeNodeStateV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+	if keys, ok := rawState["public"].([]interface{}); ok && len(keys) > 0 {
+		log.Printf("[INFO] Upgrading tir_node state to version 1, %d keys stay in the deprecated public", len(keys))
+	}
+	rawState["ssh_key_ids"] = []interface{}{}
+	return rawState, nil
+}
+
+// resourceNodeV0 is the tir_node schema at version 0. It only decodes old state and must not
+// change when the resource schema does.
+func resourceNodeV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"node_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the node. Example: 'node-020315084646'. This is a required field and must be unique.",
+			},
+			"image_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the image used for the node. This is typically used in the case of notebooks.",
+			},
+			"image_version": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The version of the image used for the node.",
+			},
+			"sku_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The SKU (Stock Keeping Unit) name for the node. This defines the type of resource being deployed.",
+			},
+			"sku_type": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The SKU type for the node. This defines whether the node is billed hourly or on a committed basis.",
+				ValidateFunc: validation.StringInSlice([]string{
+					"hourly",
+					"committed",
+				}, false),
+			},
+			"committed_days": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "The number of days the node is committed for. This is used for billing and resource allocation.",
+			},
+			"currency": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The currency used for billing the node. Supported values are 'INR' and 'USD'.",
+				ValidateFunc: validation.StringInSlice([]string{
+					"INR",
+					"USD",
+				}, false),
+			},
+			"location": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The location where the node is created. Example: 'Delhi' or 'Mumbai'.",
+				ValidateFunc: validation.StringInSlice([]string{
+					"Delhi",
+				}, false),
+			},
+			"active_iam": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The IAM (Identity and Access Management) role associated with the node.",
+			},
+			"project_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the project where the node is deployed.",
+			},
+			"team_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the team that owns the node.",
+			},
+			"cluster_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "tir-cluster",
+				Description: "The type of cluster the node belongs to. Default is 'tir-cluster'.",
+			},
+			"disk_size": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     30,
+				Description: "The size of the disk (in GB) allocated for the node. Default is 30 GB.",
+			},
+			"enable_ssh": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Indicates whether SSH access is enabled for the node. Default is false.",
+			},
+			"image_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "pre-built",
+				Description: "The type of image used for the node. Default is 'pre-built'.",
+			},
+			"is_jupyterlab_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Indicates whether JupyterLab is enabled for the node. Default is true.",
+			},
+			"notebook_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "new",
+				Description: "The type of notebook associated with the node. Default is 'new'.",
+			},
+			"notebook_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "The URL of the notebook associated with the node.",
+			},
+			"category": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "notebook",
+				Description: "The category of the node. Default is 'notebook'.",
+			},
+			"sfs_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "/mnt/sfs",
+				Description: "The path for shared file storage. Default is '/mnt/sfs'.",
+			},
+			"add_ons": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "A list of add-ons associated with the node.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"dataset_id_list": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "A list of dataset IDs associated with the node.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"public": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "A list of public configurations for the node.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The current status of the node. This is computed automatically.",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The timestamp when the node was created. This is computed automatically.",
+			},
+			"notebook_url_at_tir": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL of the notebook at TIR (Tensor Inference Resource). This is computed automatically.",
+			},
+			"instance_type": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The type of instance for the node. Supported values are 'free_usage' and 'paid_usage'.",
+				ValidateFunc: validation.StringInSlice([]string{
+					"free_usage",
+					"paid_usage",
+				}, false),
+			},
+			"committed_instance_policy": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "The policy for committed instances. This defines how committed instances are managed and billed.",
+			},
+			"stop_node": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Indicates whether to stop the node. Default is false.",
+			},
+		},
+	}
+}
@@ -0,0 +1,59 @@
+package notebook
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+)
+
+func TestUpgradeNodeStateV0(t *testing.T) {
+	cases := []struct {
+		name  string
+		state map[string]interface{}
+		want  map[string]interface{}
+	}{
+		{
+			name:  "public keys stay",
+			state: map[string]interface{}{"public": []interface{}{"ssh-ed25519 AAAA one", "ssh-rsa AAAA two"}},
+			want: map[string]interface{}{
+				"public":      []interface{}{"ssh-ed25519 AAAA one", "ssh-rsa AAAA two"},
+				"ssh_key_ids": []interface{}{},
+			},
+		},
+		{
+			name:  "no public keys",
+			state: map[string]interface{}{"node_name": "node"},
+			want:  map[string]interface{}{"node_name": "node", "ssh_key_ids": []interface{}{}},
+		},
+		{
+			name:  "no state",
+			state: nil,
+			want:  nil,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := upgradeNodeStateV0(context.Background(), tc.state, nil)
+			if err != nil {
+				t.Fatalf("upgradeNodeStateV0() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("upgradeNodeStateV0() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNodeStateUpgraders(t *testing.T) {
+	upgraders := nodeStateUpgraders()
+	if got, want := len(upgraders), ResourceNode().SchemaVersion; got != want {
+		t.Fatalf("%d state upgraders, want one per schema version before %d", got, want)
+	}
+	// State written by version 0 of the provider.
+	v0 := `{"id":"5","node_name":"node","image_name":"img","image_version":"v1","sku_name":"C3.8GB","sku_type":"hourly","committed_days":0,"currency":"INR","location":"Delhi","active_iam":"i","project_id":"p","team_id":"t","cluster_type":"tir-cluster","disk_size":30,"enable_ssh":false,"image_type":"pre-built","is_jupyterlab_enabled":true,"notebook_type":"new","notebook_url":"","category":"notebook","sfs_path":"/mnt/sfs","add_ons":null,"dataset_id_list":null,"public":["ssh-ed25519 AAAA one"],"status":"running","created_at":"2026-01-01T00:00:00Z","notebook_url_at_tir":"","instance_type":"paid_usage","committed_instance_policy":"","stop_node":false}`
+	if _, err := ctyjson.Unmarshal([]byte(v0), upgraders[0].Type); err != nil {
+		t.Errorf("the version 0 schema does not decode version 0 state: %v", err)
+	}
+}
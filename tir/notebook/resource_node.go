@@ -5,34 +5,42 @@ import (
 	"log"
 	"math"
 	"strconv"
-	"strings"
+	"time"
 
 	"github.com/e2eterraformprovider/terraform-provider-tir/models"
 
 	"github.com/e2eterraformprovider/terraform-provider-tir/client"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/naming"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/notfound"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/identity"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/labels"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func ResourceNode() *schema.Resource {
-	return &schema.Resource{
+	resource := &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"node_name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The name of the node. Example: 'node-020315084646'. This is a required field and must be unique.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"node_name", "name_prefix"},
+				Description:  "The name of the node. Example: 'node-020315084646'. It must be unique. Either node_name or name_prefix must be set.",
 			},
+			"name_prefix": naming.PrefixSchema("node_name", "node"),
 			"image_name": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The name of the image used for the node. This is typically used in the case of notebooks.",
+				Optional:    true,
+				Description: "The name of the pre-built image used for the node, as listed by tir_node_images. Required when image_type is 'pre-built'.",
 			},
 			"image_version": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The version of the image used for the node.",
+				Optional:    true,
+				Description: "The version of the pre-built image used for the node. Required when image_type is 'pre-built'.",
 			},
 			"sku_name": {
 				Type:        schema.TypeString,
@@ -96,7 +104,7 @@ func ResourceNode() *schema.Resource {
 				Type:        schema.TypeInt,
 				Optional:    true,
 				Default:     30,
-				Description: "The size of the disk (in GB) allocated for the node. Default is 30 GB.",
+				Description: "The size of the disk (in GB) allocated for the node. It can be grown in place but not shrunk. Default is 30 GB.",
 			},
 			"enable_ssh": {
 				Type:        schema.TypeBool,
@@ -105,11 +113,13 @@ func ResourceNode() *schema.Resource {
 				Description: "Indicates whether SSH access is enabled for the node. Default is false.",
 			},
 			"image_type": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "pre-built",
-				Description: "The type of image used for the node. Default is 'pre-built'.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      imageTypePreBuilt,
+				ValidateFunc: validation.StringInSlice([]string{imageTypePreBuilt, imageTypeCustom}, false),
+				Description:  "The type of image used for the node. Supported values are 'pre-built', set with image_name and image_version, and 'custom', set with custom_image. Default is 'pre-built'.",
 			},
+			"custom_image": customImageSchema(),
 			"is_jupyterlab_enabled": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -147,22 +157,43 @@ func ResourceNode() *schema.Resource {
 				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
 			"dataset_id_list": {
-				Type:        schema.TypeList,
-				Optional:    true,
-				Description: "A list of dataset IDs associated with the node.",
-				Elem:        &schema.Schema{Type: schema.TypeString},
+				Type:          schema.TypeList,
+				Optional:      true,
+				ConflictsWith: []string{"dataset_mount"},
+				Description:   "A list of dataset IDs mounted on the node when it is created. They are not read back nor updated, use dataset_mount instead. While it is set the mounts of the node are not read into dataset_mount.",
+				Deprecated:    "Use dataset_mount blocks instead. Datasets in dataset_id_list are only mounted when the node is created.",
+				Elem:          &schema.Schema{Type: schema.TypeString},
 			},
+			"dataset_mount": datasetMountSchema(),
 			"public": {
 				Type:        schema.TypeList,
 				Optional:    true,
-				Description: "A list of public configurations for the node.",
+				Description: "A list of SSH public keys authorized on the node when it is created. They are not read back nor updated, use ssh_key_ids instead.",
+				Deprecated:  "Use ssh_key_ids with tir_ssh_key resources instead. Keys in public are only sent when the node is created.",
 				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
+			"ssh_key_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The IDs of the saved SSH keys (tir_ssh_key) authorized on the node. Keys added or removed here are attached to or detached from the node in place. Only the keys listed here are managed, keys attached through public or in the TIR console are left as they are, except on import where every attached key is recorded.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringMatch(sshKeyIDPattern, "must be the numeric ID of a saved SSH key"),
+				},
+			},
 			"status": {
 				Type:        schema.TypeString,
 				Computed:    true,
 				Description: "The current status of the node. This is computed automatically.",
 			},
+			"labels":     labels.Schema("node"),
+			"labels_all": labels.AllSchema("node"),
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Prevents Terraform from deleting the node while true. Set it to false in a separate apply before destroying or replacing the node. Default is false.",
+			},
 			"created_at": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -192,18 +223,58 @@ func ResourceNode() *schema.Resource {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     false,
-				Description: "Indicates whether to stop the node. Default is false.",
+				Description: "Stops the node when set to true and starts it again when set back to false. It is not refreshed from the node status, so a node stopped or started by the tir_node_stop and tir_node_start actions or by auto_shutdown is left as it is. Default is false.",
+			},
+			"auto_shutdown": autoShutdownSchema(),
+			"allow_stop_for_update": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Allows the provider to stop a running hourly node to change its plan or image, and to start it again once the change is applied, all in one apply. Default is false.",
+			},
+			"estimated_hourly_cost": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "The estimated hourly cost of the node in the selected currency, derived from the SKU catalog.",
+			},
+			"estimated_monthly_cost": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "The estimated monthly (730 hours) cost of the node in the selected currency.",
+			},
+			"committed_total_cost": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "The total cost of the committed period for committed SKUs. Zero for hourly SKUs.",
 			},
 		},
 		CreateContext: resourceCreateNode,
 		UpdateContext: resourceUpdateNode,
 		ReadContext:   resourceReadNode,
 		DeleteContext: resourceDeleteNode,
+		CustomizeDiff: customdiff.All(customizeNodeImageDiff, customizeNodeDiff, customizeNodeDiskDiff, customizeNodeDatasetMountDiff, customizeNodeAutoShutdownDiff, labels.CustomizeDiff),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+		},
+		SchemaVersion:  1,
+		StateUpgraders: nodeStateUpgraders(),
+		Identity:       identity.Schema(),
+		Importer: &schema.ResourceImporter{
+			StateContext: identity.ImportState,
+		},
 	}
+	for key, value := range connectionSchema() {
+		resource.Schema[key] = value
+	}
+	return resource
 }
 
 func resourceCreateNode(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*client.Client)
+	if _, err := naming.Resolve(d, "node_name"); err != nil {
+		return diag.FromErr(err)
+	}
 	check_flag_for_stop_node := d.Get("stop_node").(bool)
 	if check_flag_for_stop_node {
 		return diag.Errorf("you can't give the stop_node as True while creating the notebook")
@@ -231,6 +302,11 @@ func resourceCreateNode(ctx context.Context, d *schema.ResourceData, m interface
 		CommittedInstancePolicy: d.Get("committed_instance_policy").(string),
 		CommittedDays:           d.Get("committed_days").(int),
 		PublicSSHKeys:           convertStringList(d.Get("public").([]interface{})),
+		SSHKeyIDs:               expandSSHKeyIDs(d.Get("ssh_key_ids").(*schema.Set)),
+		DatasetMounts:           expandDatasetMounts(d.Get("dataset_mount").(*schema.Set).List()),
+		CustomImage:             expandCustomImage(d),
+		AutoShutdown:            expandAutoShutdown(d),
+		Labels:                  labels.Expand(d, m),
 	}
 
 	projectID := d.Get("project_id").(string)
@@ -250,74 +326,17 @@ func resourceCreateNode(ctx context.Context, d *schema.ResourceData, m interface
 	}
 	d.Set("status", data["status"].(string))
 	d.Set("created_at", data["created_at"].(string))
-	return nil
-}
-
-func resourceUpdateNode(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	apiClient := m.(*client.Client)
-	var diags diag.Diagnostics
-	nodeID := d.Id()
-	projectID := d.Get("project_id").(string)
-	teamID := d.Get("team_id").(string)
-	activeIAM := d.Get("active_iam").(string)
-	flag := d.Get("stop_node").(bool)
-
-
-	if d.HasChange("node_name") {
-		_ , new := d.GetChange("node_name")
-		newName := new.(string)
-		_ , err := apiClient.UpdateNodeName(nodeID, projectID, teamID, activeIAM, newName)
-		if err != nil {
-			return diag.Errorf("some problem occured %e",err)
-		}
-
-	}else if d.HasChange("stop_node") {
-		if d.Get("sku_type") == "committed" {
-			d.Set("stop_node", false)
-			return diag.Errorf("You cant stop a committed node")
-		}
-		_, err := apiClient.UpdateStartStopNode(nodeID, projectID, teamID, activeIAM, flag)
-		if err != nil {
-			d.Set("stop_node", false)
-			return diag.Errorf("Not able to stop/start node")
-		}
-	}else if d.HasChange("sku_type") || d.HasChange("sku_name") || d.HasChange("committed_days") || d.HasChange("committed_instance_policy") {
-		old_sku_type, _ := d.GetChange("sku_type")
-		if old_sku_type == "committed" {
-			return diag.Errorf("you cannot change plan in committed plan")
-		}
-		if !d.HasChange("sku_type") && d.Get("status") != "stopped" {
-			return diag.Errorf("You have to stop the node first to change plan")
-		}
-		node := models.NodeAction{
-			SKUType:                 d.Get("sku_type").(string),
-			SKUName:                 d.Get("sku_name").(string),
-			Location:                d.Get("location").(string),
-			Currency:                d.Get("currency").(string),
-			Category:                d.Get("category").(string),
-			CommittedInstancePolicy: d.Get("committed_instance_policy").(string),
-			CommittedDays:           d.Get("committed_days").(int),
-		}
-
-		_, err := apiClient.UpdatePlanNode(&node, projectID, teamID, activeIAM, nodeID)
-		if err != nil {
-			return diag.Errorf("Plan changing failed")
-		}
-		d.Set("stop_node", false)
-	} else if d.HasChange("image_name") || d.HasChange("image_version") {
-		node := models.ImageDetail{
-			ImageName:           d.Get("image_name").(string),
-			ImageVersion:        d.Get("image_version").(string),
-			IsJupyterLabEnabled: d.Get("is_jupyterlab_enabled").(bool),
-			ImageType:           d.Get("image_type").(string),
-		}
-		response , err := apiClient.UpdateImage(&node, projectID, teamID, activeIAM, nodeID)
-		if err != nil {
-			return diag.Errorf("Image Update failed")
-		}
-		log.Println("response at image version",response)
+	if err := identity.Set(d); err != nil {
+		return diag.FromErr(err)
 	}
-	return diags
+	if err := waitForNodeStatus(ctx, client, d.Id(), projectID, teamID, activeIAM, "running", d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.Errorf("Node %s was created but did not become running: %s", d.Id(), err)
+	}
+	if client.DryRun() {
+		// The node only exists in the dry run record, there is nothing to read back.
+		return nil
+	}
+	return resourceReadNode(ctx, d, m)
 }
 
 func resourceReadNode(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -331,14 +350,14 @@ func resourceReadNode(ctx context.Context, d *schema.ResourceData, m interface{}
 
 	response, err := apiClient.GetNode(nodeID, projectID, teamID, activeIAM)
 	if err != nil {
-		if strings.Contains(err.Error(), "404 Not Found") {
-			log.Println("[INFO] Node not found, setting ID to empty")
-			d.SetId("")
-			return nil
+		if client.IsNotFound(err) {
+			return notfound.Remove(d, "node")
 		}
 		log.Println("[ERROR] Error fetching node:", err)
 		return diag.Errorf("Error finding item with id: %s - %v", nodeID, err)
 	}
+	// Only an imported node has no status yet.
+	imported := d.Get("status").(string) == ""
 	data := response["data"].(map[string]interface{})
 	image_details := data["image_details"].(map[string]interface{})
 	sku_details := data["sku_details"].(map[string]interface{})
@@ -346,22 +365,38 @@ func resourceReadNode(ctx context.Context, d *schema.ResourceData, m interface{}
 	plan := sku_details["plan"].(map[string]interface{})
 	d.Set("created_at", data["created_at"].(string))
 	d.Set("status", data["status"].(string))
-	d.Set("image_name", image_details["name"])
-	d.Set("image_version", image_details["version"])
+	d.Set("node_name", data["name"])
+	setNodeImage(d, image_details)
 	d.Set("sku_name", specs["name"])
 	d.Set("sku_type", plan["sku_type"])
 	d.Set("committed_days", plan["committed_days"])
 	d.Set("currency", plan["currency"])
+	if diskSize, ok := data["disk_size_in_gb"].(float64); ok {
+		d.Set("disk_size", int(diskSize))
+	}
 	notebook_url, ok := data["lab_url"].(string)
 	if ok {
 		d.Set("notebook_url_at_tir", notebook_url)
 	} else {
 		d.Set("notebook_url_at_tir", nil)
 	}
-	if d.Get("status") == "stopped" {
-		d.Set("stop_node", true)
-	} else {
-		d.Set("stop_node", false)
+	setNodeConnection(d, data)
+	if sshKeys, ok := data["ssh_keys"].([]interface{}); ok {
+		d.Set("ssh_key_ids", managedSSHKeyIDs(d, flattenSSHKeyIDs(sshKeys), imported))
+	}
+	// Datasets mounted through the deprecated dataset_id_list are not recorded in dataset_mount,
+	// they would show up as mounts to detach.
+	if mounts, ok := data["dataset_mounts"].([]interface{}); ok && len(d.Get("dataset_id_list").([]interface{})) == 0 {
+		d.Set("dataset_mount", flattenDatasetMounts(mounts))
+	}
+	if value, ok := data["auto_shutdown"]; ok {
+		d.Set("auto_shutdown", flattenAutoShutdown(value))
+	}
+	if err := labels.Set(d, m, data["labels"]); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := identity.Set(d); err != nil {
+		return diag.FromErr(err)
 	}
 	return diags
 	// return nil
@@ -369,6 +404,9 @@ func resourceReadNode(ctx context.Context, d *schema.ResourceData, m interface{}
 }
 
 func resourceDeleteNode(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if d.Get("deletion_protection").(bool) {
+		return diag.Errorf("Cannot delete node %s while deletion_protection is true. Set deletion_protection to false and apply before destroying it.", d.Id())
+	}
 	var diags diag.Diagnostics
 
 	apiClient := m.(*client.Client)
@@ -0,0 +1,229 @@
+package notebook
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/e2eterraformprovider/terraform-provider-tir/client"
+	"github.com/e2eterraformprovider/terraform-provider-tir/models"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/identity"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/naming"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/notfound"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// nodeImageReadyStatus is the status of a saved image once it is built and can be used by nodes.
+const nodeImageReadyStatus = "ready"
+
+// ResourceNodeImage saves the container of a node, with everything installed in it, as a custom
+// image that new nodes can run.
+func ResourceNodeImage() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"node_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the node whose container is saved, for example tir_node.<name>.id. It is only used when the image is saved, so changing it later, for example when the node is replaced, keeps the image.",
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return old != ""
+				},
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.All(
+					validation.StringLenBetween(1, naming.MaxLength),
+					validation.StringMatch(regexp.MustCompile(`^[a-z][a-z0-9-]*$`), "must start with a lowercase letter and contain only lowercase letters, digits and hyphens"),
+				),
+				Description: "The name of the image.",
+			},
+			"version": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]*$`), "must be a valid image tag, letters, digits, '_', '.' and '-'"),
+				Description:  "The version of the image, used as its tag, for example 'v1'.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "A description of the image, for example what was installed in it.",
+			},
+			"project_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the project of the node, where the image is saved.",
+			},
+			"team_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the team the project belongs to.",
+			},
+			"active_iam": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The IAM used to reach the node.",
+			},
+			"image_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The reference of the saved image, to be used as custom_image.image_url of a tir_node with image_type 'custom'.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the image. It is 'ready' once the image is built.",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The timestamp when the image was saved.",
+			},
+		},
+		CreateContext: resourceCreateNodeImage,
+		ReadContext:   resourceReadNodeImage,
+		UpdateContext: resourceUpdateNodeImage,
+		DeleteContext: resourceDeleteNodeImage,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+		},
+		Identity: identity.Schema(),
+		Importer: &schema.ResourceImporter{
+			StateContext: identity.ImportState,
+		},
+	}
+}
+
+func resourceCreateNodeImage(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+	nodeID := d.Get("node_id").(string)
+	projectID := d.Get("project_id").(string)
+	teamID := d.Get("team_id").(string)
+	activeIAM := d.Get("active_iam").(string)
+	image := models.NodeImage{
+		Name:        d.Get("name").(string),
+		Version:     d.Get("version").(string),
+		Description: d.Get("description").(string),
+	}
+	response, err := apiClient.NewNodeImage(&image, nodeID, projectID, teamID, activeIAM)
+	if err != nil {
+		return diag.Errorf("Not able to save node %s as image %s:%s: %s", nodeID, image.Name, image.Version, err)
+	}
+	data, _ := response["data"].(map[string]interface{})
+	imageID, ok := data["id"].(float64)
+	if !ok {
+		return diag.Errorf("failed to extract image ID from response")
+	}
+	d.SetId(strconv.Itoa(int(imageID)))
+	if err := identity.Set(d); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := waitForNodeImageReady(ctx, apiClient, d.Id(), projectID, teamID, activeIAM, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.Errorf("Image %s of node %s was saved but did not become ready: %s", d.Id(), nodeID, err)
+	}
+	if apiClient.DryRun() {
+		// The image only exists in the dry run record, there is nothing to read back.
+		return nil
+	}
+	return resourceReadNodeImage(ctx, d, m)
+}
+
+func resourceReadNodeImage(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+	imageID := d.Id()
+	response, err := apiClient.GetNodeImage(imageID, d.Get("project_id").(string), d.Get("team_id").(string), d.Get("active_iam").(string))
+	if err != nil {
+		if client.IsNotFound(err) {
+			return notfound.Remove(d, "node image")
+		}
+		return diag.Errorf("Error finding node image with id: %s - %v", imageID, err)
+	}
+	data := response["data"].(map[string]interface{})
+	for key, apiKey := range map[string]string{
+		"name":        "name",
+		"version":     "version",
+		"description": "description",
+		"image_url":   "image_url",
+		"status":      "status",
+		"created_at":  "created_at",
+	} {
+		if value, ok := data[apiKey].(string); ok {
+			d.Set(key, value)
+		}
+	}
+	// The source node is only recorded when the image is imported, later it may be replaced or
+	// gone while the image stays.
+	if d.Get("node_id").(string) == "" {
+		switch nodeID := data["notebook_id"].(type) {
+		case float64:
+			d.Set("node_id", strconv.Itoa(int(nodeID)))
+		case string:
+			d.Set("node_id", nodeID)
+		}
+	}
+	if err := identity.Set(d); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// resourceUpdateNodeImage has nothing to apply. Every argument but node_id is ForceNew, and
+// changes to node_id are ignored once the image is saved.
+func resourceUpdateNodeImage(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourceReadNodeImage(ctx, d, m)
+}
+
+func resourceDeleteNodeImage(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+	err := apiClient.DeleteNodeImage(d.Id(), d.Get("project_id").(string), d.Get("team_id").(string), d.Get("active_iam").(string))
+	if err != nil && !client.IsNotFound(err) {
+		return diag.Errorf("Not able to delete node image %s: %s", d.Id(), err)
+	}
+	d.SetId("")
+	return nil
+}
+
+// waitForNodeImageReady polls the saved image until it is built. It gives up when the build
+// fails or the timeout passes. In dry run mode nothing is built, so it returns right away.
+func waitForNodeImageReady(ctx context.Context, apiClient *client.Client, imageID string, projectID string, teamID string, activeIAM string, timeout time.Duration) error {
+	if apiClient.DryRun() {
+		log.Printf("[INFO] dry run: not waiting for node image %s to be %q", imageID, nodeImageReadyStatus)
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	for {
+		response, err := apiClient.GetNodeImage(imageID, projectID, teamID, activeIAM)
+		if err != nil {
+			return err
+		}
+		status := ""
+		if data, ok := response["data"].(map[string]interface{}); ok {
+			status, _ = data["status"].(string)
+		}
+		log.Printf("[INFO] Node image %s is %q, waiting for %q", imageID, status, nodeImageReadyStatus)
+		if strings.EqualFold(status, nodeImageReadyStatus) {
+			return nil
+		}
+		if strings.EqualFold(status, "failed") || strings.EqualFold(status, "error") {
+			return fmt.Errorf("the build of node image %s is %q", imageID, status)
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for node image %s to be %q, it is %q", imageID, nodeImageReadyStatus, status)
+		case <-time.After(nodePollInterval):
+		}
+	}
+}
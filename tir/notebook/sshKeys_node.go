@@ -0,0 +1,78 @@
+package notebook
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/e2eterraformprovider/terraform-provider-tir/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// sshKeyIDPattern matches the IDs of saved SSH keys.
+var sshKeyIDPattern = regexp.MustCompile(`^[0-9]+$`)
+
+// expandSSHKeyIDs converts ssh_key_ids to the numeric IDs the API takes. The IDs are validated
+// at plan time, so every element converts.
+func expandSSHKeyIDs(ids *schema.Set) []int {
+	result := make([]int, 0, ids.Len())
+	for _, id := range ids.List() {
+		value, _ := strconv.Atoi(id.(string))
+		result = append(result, value)
+	}
+	return result
+}
+
+// flattenSSHKeyIDs returns the IDs of the keys GetNode reports as attached to a node.
+func flattenSSHKeyIDs(sshKeys []interface{}) []string {
+	ids := []string{}
+	for _, item := range sshKeys {
+		key, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if id, ok := key["id"].(float64); ok {
+			ids = append(ids, strconv.Itoa(int(id)))
+		}
+	}
+	return ids
+}
+
+// managedSSHKeyIDs returns the keys of ssh_key_ids that are still attached to the node. Keys
+// attached through public or in the TIR console are left out, they are not managed here and the
+// next apply would detach them. When the node is imported every attached key is recorded.
+func managedSSHKeyIDs(d *schema.ResourceData, attached []string, imported bool) []string {
+	if imported {
+		return attached
+	}
+	managed := d.Get("ssh_key_ids").(*schema.Set)
+	ids := []string{}
+	for _, id := range attached {
+		if managed.Contains(id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// updateNodeSSHKeys attaches the keys added to ssh_key_ids and detaches the removed ones. Keys
+// are attached first, so a node never loses access it is meant to keep.
+func updateNodeSSHKeys(d *schema.ResourceData, apiClient *client.Client) error {
+	oldIDs, newIDs := d.GetChange("ssh_key_ids")
+	added := newIDs.(*schema.Set).Difference(oldIDs.(*schema.Set))
+	removed := oldIDs.(*schema.Set).Difference(newIDs.(*schema.Set))
+	nodeID := d.Id()
+	projectID := d.Get("project_id").(string)
+	teamID := d.Get("team_id").(string)
+	activeIAM := d.Get("active_iam").(string)
+	if added.Len() > 0 {
+		if err := apiClient.UpdateNodeSSHKeys(nodeID, projectID, teamID, activeIAM, expandSSHKeyIDs(added), true); err != nil {
+			return err
+		}
+	}
+	if removed.Len() > 0 {
+		if err := apiClient.UpdateNodeSSHKeys(nodeID, projectID, teamID, activeIAM, expandSSHKeyIDs(removed), false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
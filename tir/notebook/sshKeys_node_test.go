@@ -0,0 +1,85 @@
+package notebook
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestReadNodeSSHKeyIDs(t *testing.T) {
+	cases := []struct {
+		name     string
+		managed  []interface{}
+		attached []interface{}
+		imported bool
+		want     []string
+	}{
+		{
+			name:     "keys attached elsewhere are left out",
+			managed:  []interface{}{"1", "2"},
+			attached: []interface{}{1, 2, 3},
+			want:     []string{"1", "2"},
+		},
+		{
+			name:     "detached key shows as drift",
+			managed:  []interface{}{"1", "2"},
+			attached: []interface{}{1},
+			want:     []string{"1"},
+		},
+		{
+			name:     "keys of public only",
+			attached: []interface{}{4},
+			want:     []string{},
+		},
+		{
+			name:     "import records every key",
+			attached: []interface{}{1, 3},
+			imported: true,
+			want:     []string{"1", "3"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			f, apiClient := newFakeTIR(t)
+			var keys []interface{}
+			for _, id := range tc.attached {
+				keys = append(keys, map[string]interface{}{"id": id})
+			}
+			f.node["ssh_keys"] = keys
+			state := nodeTestState(t, nodeTestConfig(map[string]interface{}{"ssh_key_ids": tc.managed}))
+			if tc.imported {
+				state.Attributes["status"] = ""
+			}
+			refreshed := readNode(t, apiClient, state)
+			got := []string{}
+			for _, id := range ResourceNode().Data(refreshed).Get("ssh_key_ids").(*schema.Set).List() {
+				got = append(got, id.(string))
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("ssh_key_ids = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestUpdateNodeSSHKeysKeepsUnmanagedKeys(t *testing.T) {
+	f, apiClient := newFakeTIR(t)
+	f.node["ssh_keys"] = []interface{}{map[string]interface{}{"id": 1}, map[string]interface{}{"id": 3}}
+	state := readNode(t, apiClient, nodeTestState(t, nodeTestConfig(map[string]interface{}{
+		"public":      []interface{}{"ssh-ed25519 AAAA legacy"},
+		"ssh_key_ids": []interface{}{"1"},
+	})))
+	_, diags := applyNode(t, apiClient, state, nodeTestConfig(map[string]interface{}{
+		"public":      []interface{}{"ssh-ed25519 AAAA legacy"},
+		"ssh_key_ids": []interface{}{"1", "2"},
+	}))
+	if diags.HasError() {
+		t.Fatalf("apply: %v", diags)
+	}
+	if want := []string{"attach_ssh_keys"}; !reflect.DeepEqual(f.calls, want) {
+		t.Errorf("calls = %v, want %v", f.calls, want)
+	}
+}
@@ -0,0 +1,213 @@
+package notebook
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/e2eterraformprovider/terraform-provider-tir/client"
+	"github.com/e2eterraformprovider/terraform-provider-tir/models"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/labels"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// nodePlanKeys are the attributes applied by a plan change.
+var nodePlanKeys = []string{"sku_type", "sku_name", "committed_days", "committed_instance_policy"}
+
+// nodeUpdateStep is one API call of a node update together with the attributes it applies.
+type nodeUpdateStep struct {
+	name  string
+	keys  []string
+	apply func() error
+}
+
+// resourceUpdateNode applies every changed attribute of the node, one API call after the other
+// in an order TIR accepts: rename, labels, SSH keys, dataset mounts, auto shutdown, stop, plan
+// change, disk resize, image update and start. When a step fails, the attributes of that step
+// and of the steps after it are put back to their prior values, so the state only records what
+// was applied. With allow_stop_for_update a running node is stopped around a plan change or
+// image update and started again afterwards. The node is read back at the end, so the status
+// and connection details in state match the updated node.
+func resourceUpdateNode(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+	nodeID := d.Id()
+	projectID := d.Get("project_id").(string)
+	teamID := d.Get("team_id").(string)
+	activeIAM := d.Get("active_iam").(string)
+	timeout := d.Timeout(schema.TimeoutUpdate)
+
+	// stop_node only says what to do when it changes. Whether the node is stopped right now is
+	// taken from the refreshed status, as actions and auto_shutdown stop and start it too.
+	stopped := strings.EqualFold(d.Get("status").(string), "stopped")
+	stopping := d.HasChange("stop_node") && d.Get("stop_node").(bool) && !stopped
+	starting := d.HasChange("stop_node") && !d.Get("stop_node").(bool) && stopped
+	changingPlan := d.HasChanges(nodePlanKeys...)
+	changingImage := d.HasChanges(nodeImageKeys...)
+	autoStop := d.Get("allow_stop_for_update").(bool) && (changingPlan || changingImage) &&
+		!stopping && !stopped && d.Get("sku_type") != "committed"
+
+	stopNode := func() error {
+		if _, err := apiClient.UpdateStartStopNode(nodeID, projectID, teamID, activeIAM, true); err != nil {
+			return err
+		}
+		return waitForNodeStatus(ctx, apiClient, nodeID, projectID, teamID, activeIAM, "stopped", timeout)
+	}
+	startNode := func() error {
+		if _, err := apiClient.UpdateStartStopNode(nodeID, projectID, teamID, activeIAM, false); err != nil {
+			return err
+		}
+		return waitForNodeStatus(ctx, apiClient, nodeID, projectID, teamID, activeIAM, "running", timeout)
+	}
+
+	var steps []nodeUpdateStep
+	if d.HasChange("node_name") {
+		steps = append(steps, nodeUpdateStep{name: "rename", keys: []string{"node_name"}, apply: func() error {
+			_, err := apiClient.UpdateNodeName(nodeID, projectID, teamID, activeIAM, d.Get("node_name").(string))
+			return err
+		}})
+	}
+	if d.HasChanges("labels", "labels_all") {
+		steps = append(steps, nodeUpdateStep{name: "labels update", keys: []string{"labels", "labels_all"}, apply: func() error {
+			return labels.Update(d, m, client.NodeCollection)
+		}})
+	}
+	if d.HasChange("ssh_key_ids") {
+		steps = append(steps, nodeUpdateStep{name: "SSH keys update", keys: []string{"ssh_key_ids"}, apply: func() error {
+			return updateNodeSSHKeys(d, apiClient)
+		}})
+	}
+	if d.HasChange("dataset_mount") {
+		steps = append(steps, nodeUpdateStep{name: "dataset mounts update", keys: []string{"dataset_mount"}, apply: func() error {
+			return updateNodeDatasetMounts(d, apiClient)
+		}})
+	}
+	if d.HasChange("auto_shutdown") {
+		steps = append(steps, nodeUpdateStep{name: "auto shutdown update", keys: []string{"auto_shutdown"}, apply: func() error {
+			return apiClient.UpdateNodeAutoShutdown(nodeID, projectID, teamID, activeIAM, expandAutoShutdown(d))
+		}})
+	}
+	if stopping {
+		steps = append(steps, nodeUpdateStep{name: "stop", keys: []string{"stop_node"}, apply: stopNode})
+	}
+	if autoStop {
+		steps = append(steps, nodeUpdateStep{name: "stop for update", apply: stopNode})
+	}
+	if changingPlan {
+		steps = append(steps, nodeUpdateStep{name: "plan change", keys: nodePlanKeys, apply: func() error {
+			node := models.NodeAction{
+				SKUType:                 d.Get("sku_type").(string),
+				SKUName:                 d.Get("sku_name").(string),
+				Location:                d.Get("location").(string),
+				Currency:                d.Get("currency").(string),
+				Category:                d.Get("category").(string),
+				CommittedInstancePolicy: d.Get("committed_instance_policy").(string),
+				CommittedDays:           d.Get("committed_days").(int),
+			}
+			_, err := apiClient.UpdatePlanNode(&node, projectID, teamID, activeIAM, nodeID)
+			return err
+		}})
+	}
+	if d.HasChange("disk_size") {
+		steps = append(steps, nodeUpdateStep{name: "disk resize", keys: []string{"disk_size"}, apply: func() error {
+			return apiClient.ResizeNodeDisk(nodeID, projectID, teamID, activeIAM, d.Get("disk_size").(int))
+		}})
+	}
+	if changingImage {
+		steps = append(steps, nodeUpdateStep{name: "image update", keys: nodeImageKeys, apply: func() error {
+			node := models.ImageDetail{
+				ImageName:           d.Get("image_name").(string),
+				ImageVersion:        d.Get("image_version").(string),
+				IsJupyterLabEnabled: d.Get("is_jupyterlab_enabled").(bool),
+				ImageType:           d.Get("image_type").(string),
+				CustomImage:         expandCustomImage(d),
+			}
+			response, err := apiClient.UpdateImage(&node, projectID, teamID, activeIAM, nodeID)
+			log.Println("response at image version", client.RedactJSON(response))
+			return err
+		}})
+	}
+	if starting {
+		steps = append(steps, nodeUpdateStep{name: "start", keys: []string{"stop_node"}, apply: startNode})
+	}
+	if autoStop {
+		steps = append(steps, nodeUpdateStep{name: "start after update", apply: startNode})
+	}
+
+	// Refuse changes TIR does not allow before anything is sent, so a node is never left half
+	// updated because of a check that could have been made upfront.
+	oldSKUType, _ := d.GetChange("sku_type")
+	var problem string
+	switch {
+	case d.HasChange("stop_node") && d.Get("sku_type") == "committed":
+		problem = "You cant stop a committed node"
+	case changingPlan && oldSKUType == "committed":
+		problem = "you cannot change plan in committed plan"
+	case changingPlan && !d.HasChange("sku_type") && !stopping && !autoStop && !stopped:
+		problem = "You have to stop the node first to change plan, set stop_node to true in the same apply or set allow_stop_for_update"
+	}
+	if problem != "" {
+		revertNodeSteps(d, steps)
+		return diag.Errorf("%s", problem)
+	}
+
+	for i, step := range steps {
+		log.Printf("[INFO] Node %s update: %s", nodeID, step.name)
+		if err := step.apply(); err != nil {
+			revertNodeSteps(d, steps[i:])
+			diags := diag.Errorf("Updating node %s failed at the %s step: %s. %s", nodeID, step.name, err, describeAppliedSteps(steps[:i]))
+			if autoStop && hasNodeStep(steps[:i], "stop for update") && step.name != "start after update" {
+				// Do not leave a node stopped only because of allow_stop_for_update.
+				if err := startNode(); err != nil {
+					diags = append(diags, diag.Diagnostic{
+						Severity: diag.Warning,
+						Summary:  fmt.Sprintf("Node %s was left stopped", nodeID),
+						Detail:   fmt.Sprintf("The node was stopped for the update and could not be started again: %s", err),
+					})
+				}
+			}
+			return diags
+		}
+	}
+	if apiClient.DryRun() {
+		// Nothing was changed in TIR, reading the node back would undo the planned values.
+		return nil
+	}
+	return resourceReadNode(ctx, d, m)
+}
+
+// revertNodeSteps puts the attributes of steps back to their prior values.
+func revertNodeSteps(d *schema.ResourceData, steps []nodeUpdateStep) {
+	for _, step := range steps {
+		for _, key := range step.keys {
+			old, _ := d.GetChange(key)
+			d.Set(key, old)
+		}
+	}
+}
+
+// hasNodeStep reports whether steps contains the step called name.
+func hasNodeStep(steps []nodeUpdateStep, name string) bool {
+	for _, step := range steps {
+		if step.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// describeAppliedSteps tells which attribute changes of a failed update were applied. Steps
+// without attributes, such as the stop for allow_stop_for_update, are left out.
+func describeAppliedSteps(steps []nodeUpdateStep) string {
+	var names []string
+	for _, step := range steps {
+		if len(step.keys) > 0 {
+			names = append(names, step.name)
+		}
+	}
+	if len(names) == 0 {
+		return "Nothing was changed."
+	}
+	return fmt.Sprintf("Already applied and kept in the state: %s.", strings.Join(names, ", "))
+}
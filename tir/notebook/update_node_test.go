@@ -0,0 +1,181 @@
+package notebook
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestResourceUpdateNode(t *testing.T) {
+	cases := []struct {
+		name       string
+		status     string
+		state      map[string]interface{}
+		config     map[string]interface{}
+		fail       string
+		wantCalls  []string
+		wantErr    string
+		wantKept   map[string]string
+		wantStatus string
+	}{
+		{
+			name: "steps run in order",
+			state: map[string]interface{}{
+				"ssh_key_ids": []interface{}{"1"},
+			},
+			config: map[string]interface{}{
+				"node_name":     "renamed",
+				"labels":        map[string]interface{}{"team": "ml"},
+				"ssh_key_ids":   []interface{}{"1", "2"},
+				"dataset_mount": []interface{}{map[string]interface{}{"dataset_id": "7", "mount_path": "/datasets/7"}},
+				"auto_shutdown": []interface{}{map[string]interface{}{"idle_minutes": 30}},
+				"stop_node":     true,
+				"sku_name":      "C3.16GB",
+				"disk_size":     50,
+				"image_version": "v2",
+			},
+			wantCalls: []string{"rename", "labels", "attach_ssh_keys", "attach_datasets", "auto_shutdown", "stop", "plan", "resize_workspace", "image"},
+		},
+		{
+			name:      "failed step keeps the earlier ones",
+			config:    map[string]interface{}{"node_name": "renamed", "disk_size": 50, "image_version": "v2"},
+			fail:      "resize_workspace",
+			wantCalls: []string{"rename", "resize_workspace"},
+			wantErr:   "failed at the disk resize step",
+			wantKept:  map[string]string{"node_name": "renamed", "disk_size": "30", "image_version": "v1"},
+		},
+		{
+			name:      "failed first step changes nothing",
+			config:    map[string]interface{}{"node_name": "renamed", "disk_size": 50},
+			fail:      "rename",
+			wantCalls: []string{"rename"},
+			wantErr:   "Nothing was changed.",
+			wantKept:  map[string]string{"node_name": "node", "disk_size": "30"},
+		},
+		{
+			name:      "failed stop skips the plan change",
+			config:    map[string]interface{}{"stop_node": true, "sku_name": "C3.16GB"},
+			fail:      "stop",
+			wantCalls: []string{"stop"},
+			wantErr:   "failed at the stop step",
+			wantKept:  map[string]string{"stop_node": "false", "sku_name": "C3.8GB"},
+		},
+		{
+			name:      "committed node cannot be stopped",
+			state:     map[string]interface{}{"sku_type": "committed", "committed_days": 30},
+			config:    map[string]interface{}{"sku_type": "committed", "committed_days": 30, "stop_node": true},
+			wantCalls: nil,
+			wantErr:   "You cant stop a committed node",
+			wantKept:  map[string]string{"stop_node": "false"},
+		},
+		{
+			name:      "committed plan cannot change",
+			state:     map[string]interface{}{"sku_type": "committed", "committed_days": 30},
+			config:    map[string]interface{}{"sku_type": "committed", "committed_days": 30, "sku_name": "C3.16GB", "node_name": "renamed"},
+			wantCalls: nil,
+			wantErr:   "you cannot change plan in committed plan",
+			wantKept:  map[string]string{"sku_name": "C3.8GB", "node_name": "node"},
+		},
+		{
+			name:      "running node must be stopped for a plan change",
+			config:    map[string]interface{}{"sku_name": "C3.16GB"},
+			wantCalls: nil,
+			wantErr:   "You have to stop the node first",
+			wantKept:  map[string]string{"sku_name": "C3.8GB"},
+		},
+		{
+			name:       "stopped node changes plan",
+			status:     "stopped",
+			config:     map[string]interface{}{"sku_name": "C3.16GB"},
+			wantCalls:  []string{"plan"},
+			wantStatus: "stopped",
+		},
+		{
+			name:       "allow_stop_for_update stops and starts the node",
+			state:      map[string]interface{}{"allow_stop_for_update": true},
+			config:     map[string]interface{}{"allow_stop_for_update": true, "sku_name": "C3.16GB"},
+			wantCalls:  []string{"stop", "plan", "start"},
+			wantStatus: "running",
+		},
+		{
+			name:       "allow_stop_for_update starts the node after a failure",
+			state:      map[string]interface{}{"allow_stop_for_update": true},
+			config:     map[string]interface{}{"allow_stop_for_update": true, "sku_name": "C3.16GB"},
+			fail:       "plan",
+			wantCalls:  []string{"stop", "plan", "start"},
+			wantErr:    "failed at the plan change step",
+			wantKept:   map[string]string{"sku_name": "C3.8GB"},
+			wantStatus: "running",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			f, apiClient := newFakeTIR(t)
+			state := nodeTestState(t, nodeTestConfig(tc.state))
+			if tc.status != "" {
+				f.node["status"] = tc.status
+				state.Attributes["status"] = tc.status
+			}
+			if tc.fail != "" {
+				f.fail[tc.fail] = true
+			}
+			config := nodeTestConfig(tc.state)
+			for key, value := range tc.config {
+				config[key] = value
+			}
+			updated, diags := applyNode(t, apiClient, state, config)
+			if !reflect.DeepEqual(f.calls, tc.wantCalls) {
+				t.Errorf("calls = %v, want %v", f.calls, tc.wantCalls)
+			}
+			if tc.wantErr == "" {
+				if diags.HasError() {
+					t.Fatalf("apply: %v", diags)
+				}
+			} else if !diags.HasError() || !strings.Contains(diags[0].Summary, tc.wantErr) {
+				t.Fatalf("apply error = %v, want one containing %q", diags, tc.wantErr)
+			}
+			for key, want := range tc.wantKept {
+				if got := updated.Attributes[key]; got != want {
+					t.Errorf("%s = %q in state, want %q", key, got, want)
+				}
+			}
+			if tc.wantStatus != "" && f.node["status"] != tc.wantStatus {
+				t.Errorf("node is %v, want %s", f.node["status"], tc.wantStatus)
+			}
+		})
+	}
+}
+
+func TestDescribeAppliedSteps(t *testing.T) {
+	cases := []struct {
+		name  string
+		steps []nodeUpdateStep
+		want  string
+	}{
+		{
+			name: "nothing applied",
+			want: "Nothing was changed.",
+		},
+		{
+			name:  "only the stop for the update",
+			steps: []nodeUpdateStep{{name: "stop for update"}},
+			want:  "Nothing was changed.",
+		},
+		{
+			name: "attribute steps",
+			steps: []nodeUpdateStep{
+				{name: "rename", keys: []string{"node_name"}},
+				{name: "stop for update"},
+				{name: "plan change", keys: nodePlanKeys},
+			},
+			want: "Already applied and kept in the state: rename, plan change.",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := describeAppliedSteps(tc.steps); got != tc.want {
+				t.Errorf("describeAppliedSteps() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package notebook
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/e2eterraformprovider/terraform-provider-tir/client"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/catalog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// customizeNodeDiff checks image_name, image_version and the selected SKU plan against the
+// TIR catalog at plan time, so typos are reported before the create or update request is sent,
+// and fills in the estimated cost of the selected plan.
+func customizeNodeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	keys := []string{"image_type", "image_name", "image_version", "sku_name", "sku_type", "currency", "committed_days", "active_iam"}
+	if d.Id() != "" && !d.HasChanges(keys...) {
+		return nil
+	}
+	for _, key := range keys {
+		if !d.NewValueKnown(key) {
+			log.Printf("[DEBUG] %s is unknown at plan time, skipping catalog validation", key)
+			return catalog.SetEstimatedCostUnknown(d)
+		}
+	}
+	if d.Get("image_type").(string) != imageTypePreBuilt || d.Get("image_name").(string) == "" || d.Get("image_version").(string) == "" {
+		// Custom images are not in the catalog, and a missing pre-built image is reported by
+		// customizeNodeImageDiff.
+		return catalog.SetEstimatedCostUnknown(d)
+	}
+	apiClient := m.(*client.Client)
+	activeIAM := d.Get("active_iam").(string)
+	imageName := d.Get("image_name").(string)
+	imageVersion := d.Get("image_version").(string)
+
+	images, err := apiClient.GetImages(activeIAM)
+	if err != nil {
+		return fmt.Errorf("not able to fetch the image catalog: %s", err)
+	}
+	if err := validateImage(images, imageName, imageVersion); err != nil {
+		return err
+	}
+
+	plans, err := apiClient.GetPlans(activeIAM, imageName, imageVersion)
+	if err != nil {
+		return fmt.Errorf("not able to fetch the plan catalog: %s", err)
+	}
+	plan, err := catalog.FindSKUPlan(catalog.ParseSKUPlans(plans), d.Get("sku_name").(string), d.Get("sku_type").(string), d.Get("currency").(string), d.Get("committed_days").(int))
+	if err != nil {
+		return fmt.Errorf("%s for image %s:%s", err, imageName, imageVersion)
+	}
+	return catalog.SetEstimatedCost(d, catalog.EstimateCost(plan, 1))
+}
+
+// customizeNodeDiskDiff rejects shrinking disk_size at plan time, TIR can only grow the
+// workspace of an existing node.
+func customizeNodeDiskDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.HasChange("disk_size") {
+		return nil
+	}
+	oldSize, newSize := d.GetChange("disk_size")
+	if newSize.(int) < oldSize.(int) {
+		return fmt.Errorf("disk_size cannot be reduced from %d GB to %d GB, the disk of a node can only grow. Replace the node to use a smaller disk", oldSize.(int), newSize.(int))
+	}
+	return nil
+}
+
+func validateImage(response map[string]interface{}, imageName string, imageVersion string) error {
+	data, ok := response["data"].([]interface{})
+	if !ok {
+		return nil
+	}
+	var imageNames []string
+	for _, image := range data {
+		imageMap, ok := image.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := imageMap["name"].(string)
+		imageNames = append(imageNames, name)
+		if name != imageName {
+			continue
+		}
+		var versions []string
+		versionList, _ := imageMap["versions"].([]interface{})
+		for _, version := range versionList {
+			versionMap, ok := version.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			value, _ := versionMap["version"].(string)
+			if value == imageVersion {
+				return nil
+			}
+			versions = append(versions, value)
+		}
+		return catalog.DidYouMean(fmt.Sprintf("image_version for image %s", imageName), imageVersion, versions)
+	}
+	return catalog.DidYouMean("image_name", imageName, imageNames)
+}
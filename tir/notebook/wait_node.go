@@ -0,0 +1,48 @@
+package notebook
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/e2eterraformprovider/terraform-provider-tir/client"
+)
+
+// nodePollInterval is how often the node status is polled while waiting for a transition.
+var nodePollInterval = 10 * time.Second
+
+// waitForNodeStatus polls the node until its status matches target. It gives up when the node
+// reports a failure or the timeout passes. In dry run mode nothing changed in TIR, so it returns
+// right away.
+func waitForNodeStatus(ctx context.Context, apiClient *client.Client, nodeID string, projectID string, teamID string, activeIAM string, target string, timeout time.Duration) error {
+	if apiClient.DryRun() {
+		log.Printf("[INFO] dry run: not waiting for node %s to be %q", nodeID, target)
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	for {
+		response, err := apiClient.GetNode(nodeID, projectID, teamID, activeIAM)
+		if err != nil {
+			return err
+		}
+		status := ""
+		if data, ok := response["data"].(map[string]interface{}); ok {
+			status, _ = data["status"].(string)
+		}
+		log.Printf("[INFO] Node %s is %q, waiting for %q", nodeID, status, target)
+		if strings.EqualFold(status, target) {
+			return nil
+		}
+		if strings.EqualFold(status, "failed") || strings.EqualFold(status, "error") {
+			return fmt.Errorf("node %s is %q while waiting for it to be %q", nodeID, status, target)
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for node %s to be %q, it is %q", nodeID, target, status)
+		case <-time.After(nodePollInterval):
+		}
+	}
+}
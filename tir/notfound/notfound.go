@@ -0,0 +1,25 @@
+package notfound
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Remove drops a resource that no longer exists in TIR from the state and returns a warning
+// saying so, which makes Terraform plan to create it again instead of failing the refresh.
+// kind names the resource in the warning, for example "node".
+func Remove(d *schema.ResourceData, kind string) diag.Diagnostics {
+	id := d.Id()
+	log.Printf("[WARN] %s %s not found, removing it from state", kind, id)
+	d.SetId("")
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("The %s %s no longer exists", kind, id),
+			Detail:   fmt.Sprintf("The %s was not found in TIR, it was probably deleted outside of Terraform. It has been removed from the state and will be planned for creation again.", kind),
+		},
+	}
+}
@@ -0,0 +1,12 @@
+package privateCluster
+
+import (
+	"github.com/e2eterraformprovider/terraform-provider-tir/client"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/listing"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+)
+
+// NewPrivateClusterListResource returns the tir_private_cluster list resource used by `terraform query`.
+func NewPrivateClusterListResource() list.ListResource {
+	return listing.New("tir_private_cluster", "private cluster", "name", ResourcePrivateCluster, (*client.Client).ListPrivateClusters)
+}
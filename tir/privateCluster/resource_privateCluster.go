@@ -7,8 +7,13 @@ import (
 	"strconv"
 	"strings"
 	"github.com/e2eterraformprovider/terraform-provider-tir/client"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/naming"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/notfound"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/identity"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/labels"
 	"github.com/e2eterraformprovider/terraform-provider-tir/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -16,10 +21,13 @@ func ResourcePrivateCluster() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The name of the model repository. This is a required field and must be unique.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"name", "name_prefix"},
+				Description:  "The name of the private cluster. It must be unique. Either name or name_prefix must be set.",
 			},
+			"name_prefix": naming.PrefixSchema("name", "private cluster"),
 			"nodes_count": {
 				Type:        schema.TypeInt,
 				Required:    true,
@@ -57,6 +65,14 @@ func ResourcePrivateCluster() *schema.Resource {
 				Required:    true,
 				Description: "Location for resource allocation ",
 			},
+			"labels":     labels.Schema("private cluster"),
+			"labels_all": labels.AllSchema("private cluster"),
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Prevents Terraform from deleting the private cluster while true, as deleting a committed cluster forfeits the commitment. Set it to false in a separate apply before destroying or replacing the private cluster. Default is false.",
+			},
 			"created_at": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -77,16 +93,39 @@ func ResourcePrivateCluster() *schema.Resource {
 				Required:    true,
 				Description: "This is for Identity Access Management. ",
 			},
+			"estimated_hourly_cost": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "The estimated hourly cost of the private cluster across all nodes in the selected currency, derived from the SKU catalog.",
+			},
+			"estimated_monthly_cost": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "The estimated monthly (730 hours) cost of the private cluster across all nodes in the selected currency.",
+			},
+			"committed_total_cost": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "The total cost of the committed period for committed SKUs. Zero for hourly SKUs.",
+			},
 		},
 		CreateContext: resourceCreatePrivateCluster,
 		UpdateContext: resourceUpdatePrivateCluster,
 		ReadContext:   resourceReadPrivateCluster,
 		DeleteContext: resourceDeletePrivateCluster,
+		CustomizeDiff: customdiff.All(customizePrivateClusterDiff, labels.CustomizeDiff),
+		Identity:      identity.Schema(),
+		Importer: &schema.ResourceImporter{
+			StateContext: identity.ImportState,
+		},
 	}
 }
 
 func resourceCreatePrivateCluster(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	apiClient := m.(*client.Client)
+	if _, err := naming.Resolve(d, "name"); err != nil {
+		return diag.FromErr(err)
+	}
 
 	payload := models.PrivateCluster{
 		Name:                    d.Get("name").(string),
@@ -98,6 +137,7 @@ func resourceCreatePrivateCluster(ctx context.Context, d *schema.ResourceData, m
 		Location:                d.Get("location").(string),
 		Currency:                d.Get("currency").(string),
 		Category:                "private_cloud",
+		Labels:                  labels.Expand(d, m),
 	}
 
 	response, err := apiClient.NewPrivateCluster(&payload, d.Get("project_id").(string), d.Get("team_id").(string), d.Get("active_iam").(string))
@@ -107,21 +147,22 @@ func resourceCreatePrivateCluster(ctx context.Context, d *schema.ResourceData, m
 	}
 	data := response["data"].(map[string]interface{})
 	d.SetId(strconv.Itoa(int(math.Round(data["id"].(float64)))))
+	if err := identity.Set(d); err != nil {
+		return diag.FromErr(err)
+	}
 	return nil
 }
 
 func resourceReadPrivateCluster(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	apiClient := m.(*client.Client)
-	repoID := d.Id()
+	privateClusterID := d.Id()
 
-	response, err := apiClient.GetRepo(repoID, d.Get("project_id").(string), d.Get("team_id").(string), d.Get("active_iam").(string))
+	response, err := apiClient.GetPrivateCluster(privateClusterID, d.Get("project_id").(string), d.Get("team_id").(string), d.Get("active_iam").(string))
 	if err != nil {
-		if strings.Contains(err.Error(), "404 Not Found") {
-			log.Println("[INFO] Repo not found, setting ID to empty")
-			d.SetId("")
-			return nil
+		if client.IsNotFound(err) {
+			return notfound.Remove(d, "private cluster")
 		}
-		return diag.Errorf("Error finding item with id: %s - %v", repoID, err)
+		return diag.Errorf("Error finding item with id: %s - %v", privateClusterID, err)
 	}
 	data := response["data"].(map[string]interface{})
 	sku_details := data["sku_details"].(map[string]interface{})
@@ -134,15 +175,29 @@ func resourceReadPrivateCluster(ctx context.Context, d *schema.ResourceData, m i
 	d.Set("sku_type", plan["sku_type"])
 	d.Set("committed_days", plan["committed_days"])
 	d.Set("currency", plan["currency"])
+	if err := labels.Set(d, m, data["labels"]); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := identity.Set(d); err != nil {
+		return diag.FromErr(err)
+	}
 	return nil
 }
 
 func resourceUpdatePrivateCluster(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-
-	return diag.Errorf("You cannot update anything please apply terraform refresh")
+	if d.HasChangesExcept("deletion_protection", "labels", "labels_all") {
+		return diag.Errorf("You cannot update anything please apply terraform refresh")
+	}
+	if err := labels.Update(d, m, client.PrivateClusterCollection); err != nil {
+		return diag.Errorf("Updating labels failed: %s", err)
+	}
+	return nil
 }
 
 func resourceDeletePrivateCluster(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if d.Get("deletion_protection").(bool) {
+		return diag.Errorf("Cannot delete private cluster %s while deletion_protection is true. Set deletion_protection to false and apply before destroying it.", d.Id())
+	}
 	var diags diag.Diagnostics
 	apiClient := m.(*client.Client)
 	privateClusterID := d.Id()
@@ -0,0 +1,36 @@
+package privateCluster
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/e2eterraformprovider/terraform-provider-tir/client"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/catalog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// customizePrivateClusterDiff looks up the selected SKU plan in the private cluster catalog and
+// fills in the estimated cost for the requested number of nodes.
+func customizePrivateClusterDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	keys := []string{"sku_name", "sku_type", "currency", "committed_days", "nodes_count", "active_iam"}
+	if d.Id() != "" && !d.HasChanges(keys...) {
+		return nil
+	}
+	for _, key := range keys {
+		if !d.NewValueKnown(key) {
+			log.Printf("[DEBUG] %s is unknown at plan time, skipping cost estimation", key)
+			return catalog.SetEstimatedCostUnknown(d)
+		}
+	}
+	apiClient := m.(*client.Client)
+	plans, err := apiClient.GetPlansPrivateCluster(d.Get("active_iam").(string))
+	if err != nil {
+		return fmt.Errorf("not able to fetch the plan catalog: %s", err)
+	}
+	plan, err := catalog.FindSKUPlan(catalog.ParseSKUPlans(plans), d.Get("sku_name").(string), d.Get("sku_type").(string), d.Get("currency").(string), d.Get("committed_days").(int))
+	if err != nil {
+		return err
+	}
+	return catalog.SetEstimatedCost(d, catalog.EstimateCost(plan, d.Get("nodes_count").(int)))
+}
@@ -10,19 +10,26 @@ import (
 	"github.com/e2eterraformprovider/terraform-provider-tir/tir/notebook"
 	"github.com/e2eterraformprovider/terraform-provider-tir/tir/privateCluster"
 	"github.com/e2eterraformprovider/terraform-provider-tir/tir/projects"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/sshKey"
 	"github.com/e2eterraformprovider/terraform-provider-tir/tir/teams"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 // Provider function defines the schema for authentication.
 func Provider() *schema.Provider {
+	return NewProvider(client.NewSharedClient())
+}
+
+// NewProvider returns the SDKv2 provider configuring its API client through shared, so the
+// plugin framework provider served next to it works with the same client.
+func NewProvider(shared *client.SharedClient) *schema.Provider {
 	return &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"api_endpoint": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Description: "Endpoint of e2e tir platform",
-				Default:     "https://api.e2enetworks.com/myaccount/api/v1/gpu",
+				Default:     client.DefaultApiEndpoint,
 			},
 			"auth_token": {
 				Type:        schema.TypeString,
@@ -36,6 +43,24 @@ func Provider() *schema.Provider {
 				Sensitive:   true,
 				Description: "API Key for authentication",
 			},
+			"dry_run": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, create, update and delete calls are not sent to TIR. They are recorded with method, path and body, secrets redacted, in dry_run_file and answered with synthetic responses so the apply completes. Read only calls are still sent. Default is false.",
+			},
+			"dry_run_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     client.DefaultDryRunFile,
+				Description: "The file dry run mode writes the recorded calls to, as a JSON array. It is rewritten on every recorded call of an apply. Default is 'tir-dry-run.json'.",
+			},
+			"default_labels": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Labels applied to every TIR resource managed by this provider. Labels set on a resource win over the same default key.",
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"tir_node":        notebook.ResourceNode(),
@@ -44,24 +69,38 @@ func Provider() *schema.Provider {
 			"tir_model_endpoint":   modelEndpoint.ResourceModel(),
 			"tir_integration":     integration.ResourceModelRepo(),
 			"tir_private_cluster":  privateCluster.ResourcePrivateCluster(),
+			"tir_ssh_key":          sshKey.ResourceSSHKey(),
+			"tir_node_image":       notebook.ResourceNodeImage(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"tir_node_images":       notebook.DataSourceImages(),
 			"tir_node_plans": notebook.DataSourceSKUPlans(),
+			"tir_node": notebook.DataSourceNode(),
+			"tir_nodes": notebook.DataSourceNodes(),
 			"tir_private_cluster_plans" : privateCluster.DataSourceSKUPlansPrivateCluster(),
 			"tir_model_endpoint_plans" : modelEndpoint.DataSourceSKUPlansModelEndpoint(),
 			"tir_iams" : iams.DataSourceIAMS(),
 			"tir_teams" : teams.DataSourceTeams(),
 			"tir_projects" : projects.DataSourceProjects(),
+			"tir_ssh_keys" : sshKey.DataSourceSSHKeys(),
 		},
-		ConfigureFunc: providerConfigure, // setup the API Client
+		ConfigureFunc: providerConfigure(shared), // setup the API Client
 	}
 }
 
-func providerConfigure(d *schema.ResourceData) (interface{}, error) {
-
-	api_key := d.Get("api_key").(string)
-	auth_token := d.Get("auth_token").(string)
-	api_endpoint := d.Get("api_endpoint").(string)
-	return client.NewClient(api_key, auth_token, api_endpoint), nil
+func providerConfigure(shared *client.SharedClient) schema.ConfigureFunc {
+	return func(d *schema.ResourceData) (interface{}, error) {
+		api_key := d.Get("api_key").(string)
+		auth_token := d.Get("auth_token").(string)
+		api_endpoint := d.Get("api_endpoint").(string)
+		default_labels := make(map[string]string)
+		for key, value := range d.Get("default_labels").(map[string]interface{}) {
+			default_labels[key] = value.(string)
+		}
+		dry_run_file := ""
+		if d.Get("dry_run").(bool) {
+			dry_run_file = d.Get("dry_run_file").(string)
+		}
+		return shared.Configure(api_key, auth_token, api_endpoint, default_labels, dry_run_file), nil
+	}
 }
@@ -0,0 +1,107 @@
+package sshKey
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+
+	"github.com/e2eterraformprovider/terraform-provider-tir/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// DataSourceSSHKeys lists the keys saved on the account, for example to attach keys managed
+// outside this configuration to a node.
+func DataSourceSSHKeys() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"active_iam": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The IAM of the account the keys are saved on.",
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+				Description:  "Only keys whose name matches this regular expression are returned.",
+			},
+			"ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The IDs of the returned keys, ready to be used as ssh_key_ids of a node.",
+			},
+			"ssh_keys": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The returned keys.",
+				Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+					"id": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"name": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"public_key": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"fingerprint": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+				}},
+			},
+		},
+		ReadContext: dataSourceSSHKeys,
+	}
+}
+
+func dataSourceSSHKeys(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+	activeIAM := d.Get("active_iam").(string)
+	var nameRegex *regexp.Regexp
+	if pattern, ok := d.GetOk("name_regex"); ok {
+		nameRegex = regexp.MustCompile(pattern.(string))
+	}
+
+	items, err := apiClient.ListSSHKeys(activeIAM)
+	if err != nil {
+		return diag.Errorf("Not able to list SSH keys %s", err)
+	}
+	ids := []string{}
+	keys := []map[string]interface{}{}
+	for _, item := range items {
+		key, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		id, ok := key["id"].(float64)
+		if !ok {
+			continue
+		}
+		name, _ := key["label"].(string)
+		if nameRegex != nil && !nameRegex.MatchString(name) {
+			continue
+		}
+		publicKey, _ := key["ssh_key"].(string)
+		publicKey = normalizePublicKey(publicKey)
+		// Keys TIR accepted in a format this provider cannot parse are still listed.
+		fingerprint, _ := Fingerprint(publicKey)
+		ids = append(ids, strconv.Itoa(int(id)))
+		keys = append(keys, map[string]interface{}{
+			"id":          strconv.Itoa(int(id)),
+			"name":        name,
+			"public_key":  publicKey,
+			"fingerprint": fingerprint,
+		})
+	}
+	d.Set("ids", ids)
+	d.Set("ssh_keys", keys)
+	d.SetId("ssh_keys" + activeIAM + d.Get("name_regex").(string))
+	return nil
+}
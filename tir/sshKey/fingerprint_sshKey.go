@@ -0,0 +1,68 @@
+package sshKey
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// parsePublicKey splits an authorized_keys line into its key type and decoded key blob. The
+// comment after the blob is optional and ignored.
+func parsePublicKey(publicKey string) (string, []byte, error) {
+	fields := strings.Fields(publicKey)
+	if len(fields) < 2 {
+		return "", nil, fmt.Errorf("expected an OpenSSH public key of the form '<type> <base64 key> [comment]'")
+	}
+	blob, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return "", nil, fmt.Errorf("the key is not valid base64: %v", err)
+	}
+	// The blob starts with the length prefixed key type, which must match the first field.
+	if len(blob) < 4 {
+		return "", nil, fmt.Errorf("the key is too short")
+	}
+	typeLength := int(blob[0])<<24 | int(blob[1])<<16 | int(blob[2])<<8 | int(blob[3])
+	if typeLength > len(blob)-4 || string(blob[4:4+typeLength]) != fields[0] {
+		return "", nil, fmt.Errorf("the key data does not match the key type %q", fields[0])
+	}
+	return fields[0], blob, nil
+}
+
+// Fingerprint returns the SHA256 fingerprint of publicKey in the format ssh-keygen -l prints.
+func Fingerprint(publicKey string) (string, error) {
+	_, blob, err := parsePublicKey(publicKey)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(blob)
+	return "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:]), nil
+}
+
+// normalizePublicKey drops the surrounding whitespace and trailing newline file() leaves on a key.
+func normalizePublicKey(value interface{}) string {
+	return strings.TrimSpace(value.(string))
+}
+
+func validatePublicKey(value interface{}, key string) ([]string, []error) {
+	if _, _, err := parsePublicKey(value.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%s: %v", key, err)}
+	}
+	return nil, nil
+}
+
+// customizeSSHKeyDiff plans the fingerprint of a new key, so it is known to the nodes and outputs
+// that use it before the key is saved.
+func customizeSSHKeyDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.HasChange("public_key") || !d.NewValueKnown("public_key") {
+		return nil
+	}
+	fingerprint, err := Fingerprint(d.Get("public_key").(string))
+	if err != nil {
+		return err
+	}
+	return d.SetNew("fingerprint", fingerprint)
+}
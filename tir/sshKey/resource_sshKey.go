@@ -0,0 +1,135 @@
+package sshKey
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/e2eterraformprovider/terraform-provider-tir/client"
+	"github.com/e2eterraformprovider/terraform-provider-tir/models"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/naming"
+	"github.com/e2eterraformprovider/terraform-provider-tir/tir/notfound"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ResourceSSHKey manages a public key saved on the account. Saved keys can be attached to any
+// number of nodes through their ssh_key_ids.
+func ResourceSSHKey() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"name", "name_prefix"},
+				Description:  "The name the key is saved under. Either name or name_prefix must be set.",
+			},
+			"name_prefix": naming.PrefixSchema("name", "SSH key"),
+			"public_key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				StateFunc:    normalizePublicKey,
+				ValidateFunc: validatePublicKey,
+				Description:  "The OpenSSH public key, for example the content of ~/.ssh/id_ed25519.pub. Surrounding whitespace is ignored.",
+			},
+			"fingerprint": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The SHA256 fingerprint of the key, as printed by 'ssh-keygen -l'.",
+			},
+			"active_iam": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The IAM of the account the key is saved on.",
+			},
+		},
+		CreateContext: resourceCreateSSHKey,
+		ReadContext:   resourceReadSSHKey,
+		DeleteContext: resourceDeleteSSHKey,
+		CustomizeDiff: customizeSSHKeyDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: importSSHKey,
+		},
+	}
+}
+
+func resourceCreateSSHKey(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+	name, err := naming.Resolve(d, "name")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	publicKey := normalizePublicKey(d.Get("public_key"))
+	payload := models.SSHKey{
+		Label:  name,
+		SSHKey: publicKey,
+	}
+	response, err := apiClient.NewSSHKey(&payload, d.Get("active_iam").(string))
+	if err != nil {
+		return diag.Errorf("Not able to save the SSH key %s: %s", name, err)
+	}
+	data, _ := response["data"].(map[string]interface{})
+	keyID, ok := data["id"].(float64)
+	if !ok {
+		return diag.Errorf("failed to extract SSH key ID from response")
+	}
+	d.SetId(strconv.Itoa(int(keyID)))
+	fingerprint, err := Fingerprint(publicKey)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("fingerprint", fingerprint)
+	return nil
+}
+
+func resourceReadSSHKey(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+	keyID := d.Id()
+
+	response, err := apiClient.GetSSHKey(keyID, d.Get("active_iam").(string))
+	if err != nil {
+		if client.IsNotFound(err) {
+			return notfound.Remove(d, "SSH key")
+		}
+		return diag.Errorf("Error finding SSH key with id: %s - %v", keyID, err)
+	}
+	data := response["data"].(map[string]interface{})
+	if name, ok := data["label"].(string); ok {
+		d.Set("name", name)
+	}
+	if publicKey, ok := data["ssh_key"].(string); ok {
+		publicKey = normalizePublicKey(publicKey)
+		d.Set("public_key", publicKey)
+		fingerprint, err := Fingerprint(publicKey)
+		if err != nil {
+			return diag.Errorf("TIR returned an unreadable public key for SSH key %s: %s", keyID, err)
+		}
+		d.Set("fingerprint", fingerprint)
+	}
+	return nil
+}
+
+func resourceDeleteSSHKey(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	apiClient := m.(*client.Client)
+	if err := apiClient.DeleteSSHKey(d.Id(), d.Get("active_iam").(string)); err != nil && !client.IsNotFound(err) {
+		return diag.Errorf("Not able to delete SSH key %s: %s", d.Id(), err)
+	}
+	d.SetId("")
+	return nil
+}
+
+// importSSHKey imports a saved key from an ID of the form <active_iam>/<id>.
+func importSSHKey(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("unexpected import ID %q, expected <active_iam>/<id>", d.Id())
+	}
+	d.Set("active_iam", parts[0])
+	d.SetId(parts[1])
+	return []*schema.ResourceData{d}, nil
+}